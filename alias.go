@@ -0,0 +1,105 @@
+package fuel
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// AliasRule decides which attribute is decoded when an item holds
+// more than one of a field's names (its primary name and its aliases),
+// as declared with the alias= tag option:
+//
+//	CustomerID string `dynamodb:"customer_id,alias=custId|CustomerID"`
+type AliasRule int
+
+const (
+	// AliasPreferPrimary decodes the primary name if present,
+	// otherwise the first present alias in tag order. This is the default.
+	AliasPreferPrimary AliasRule = iota
+	// AliasPreferAlias decodes the first present alias in tag order,
+	// falling back to the primary name.
+	AliasPreferAlias
+	// AliasConflictError fails decoding if more than one of the names is present.
+	AliasConflictError
+)
+
+// Aliases sets the rule a Decoder uses to pick between a field's primary name and its aliases.
+func Aliases(rule AliasRule) DecoderOption {
+	return decoderOptionFunc(func(d *Decoder) {
+		d.aliasRule = rule
+	})
+}
+
+// MarshalItemAndAliases is like MarshalItem, but also returns the aliases of
+// the item's top-level attributes, which can be removed with an update's REMOVE clause
+// to finish migrating to the primary names.
+func MarshalItemAndAliases(v interface{}) (map[string]types.AttributeValue, []string, error) {
+	return defaultEncoder.MarshalItemAndAliases(v)
+}
+
+// MarshalItemAndAliases is like MarshalItem, but also returns the aliases of the item's top-level attributes
+func (e *Encoder) MarshalItemAndAliases(v interface{}) (map[string]types.AttributeValue, []string, error) {
+	item, err := e.MarshalItem(v)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rt := indirectType(v)
+	if rt == nil || rt.Kind() != reflect.Struct {
+		return item, nil, nil
+	}
+	fields, err := cachedFields(rt, e.fieldOpts)
+	if err != nil {
+		return nil, nil, err
+	}
+	var aliases []string
+	for _, f := range fields.list {
+		aliases = append(aliases, f.aliases...)
+	}
+	return item, aliases, nil
+}
+
+// attribute finds the attribute for f in item, following the alias rule,
+// and returns its name. If nothing matches exactly, folded (from foldKeys) is used to find an inexact match.
+func (d *Decoder) attribute(item map[string]types.AttributeValue, folded map[string]string, f field) (string, bool, error) {
+	if len(f.aliases) == 0 {
+		if _, ok := item[f.name]; ok {
+			return f.name, true, nil
+		}
+		if folded != nil {
+			if key, found := folded[d.fold(f.name)]; found {
+				return key, true, nil
+			}
+		}
+		return "", false, nil
+	}
+
+	var found []string
+	if _, ok := item[f.name]; ok {
+		found = append(found, f.name)
+	}
+	for _, alias := range f.aliases {
+		if _, ok := item[alias]; ok {
+			found = append(found, alias)
+		}
+	}
+
+	switch {
+	case len(found) == 0 && folded != nil:
+		for _, name := range append([]string{f.name}, f.aliases...) {
+			if key, ok := folded[d.fold(name)]; ok {
+				return key, true, nil
+			}
+		}
+		return "", false, nil
+	case len(found) == 0:
+		return "", false, nil
+	case len(found) > 1 && d.aliasRule == AliasConflictError:
+		return "", false, fmt.Errorf("dynamodb: conflicting attributes for %s: %v", f.name, found)
+	case d.aliasRule == AliasPreferAlias && found[0] == f.name && len(found) > 1:
+		return found[1], true, nil
+	}
+	return found[0], true, nil
+}
@@ -0,0 +1,121 @@
+package fuel
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/go-cmp/cmp"
+)
+
+type aliasItem struct {
+	CustomerID string `dynamodb:"customer_id,alias=custId|CustomerID"`
+	Name       string
+}
+
+func TestUnmarshalAliases(t *testing.T) {
+	both := map[string]types.AttributeValue{
+		"customer_id": &types.AttributeValueMemberS{Value: "new"},
+		"CustomerID":  &types.AttributeValueMemberS{Value: "old"},
+	}
+
+	tests := []struct {
+		name  string
+		rule  AliasRule
+		given map[string]types.AttributeValue
+		want  string
+		err   bool
+	}{
+		{
+			name: "alias only",
+			given: map[string]types.AttributeValue{
+				"custId": &types.AttributeValueMemberS{Value: "old"},
+			},
+			want: "old",
+		},
+		{
+			name: "aliases in tag order",
+			given: map[string]types.AttributeValue{
+				"custId":     &types.AttributeValueMemberS{Value: "first"},
+				"CustomerID": &types.AttributeValueMemberS{Value: "second"},
+			},
+			want: "first",
+		},
+		{
+			name:  "prefer primary",
+			given: both,
+			want:  "new",
+		},
+		{
+			name:  "prefer alias",
+			rule:  AliasPreferAlias,
+			given: both,
+			want:  "old",
+		},
+		{
+			name:  "conflict error",
+			rule:  AliasConflictError,
+			given: both,
+			err:   true,
+		},
+		{
+			name: "no conflict",
+			rule: AliasConflictError,
+			given: map[string]types.AttributeValue{
+				"custId": &types.AttributeValueMemberS{Value: "old"},
+			},
+			want: "old",
+		},
+	}
+
+	for _, tc := range tests {
+		var got aliasItem
+		err := NewDecoder(Aliases(tc.rule)).UnmarshalItem(tc.given, &got)
+		if tc.err {
+			if err == nil {
+				t.Errorf("%s: expected error, got nil", tc.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+			continue
+		}
+		if got.CustomerID != tc.want {
+			t.Errorf("%s: want %q, got %q", tc.name, tc.want, got.CustomerID)
+		}
+	}
+}
+
+func TestUnmarshalAliasCoercionPath(t *testing.T) {
+	item := map[string]types.AttributeValue{
+		"custId": &types.AttributeValueMemberN{Value: "42"},
+	}
+	var path string
+	dec := NewDecoder(Lenient(func(c Coercion) {
+		path = c.Path
+	}))
+	var got aliasItem
+	if err := dec.UnmarshalItem(item, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.CustomerID != "42" || path != "custId" {
+		t.Errorf("want 42 from custId, got %q from %q", got.CustomerID, path)
+	}
+}
+
+func TestMarshalItemAndAliases(t *testing.T) {
+	item, aliases, err := MarshalItemAndAliases(&aliasItem{CustomerID: "c1", Name: "n"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]types.AttributeValue{
+		"customer_id": &types.AttributeValueMemberS{Value: "c1"},
+		"Name":        &types.AttributeValueMemberS{Value: "n"},
+	}
+	if diff := cmp.Diff(want, item); diff != "" {
+		t.Errorf("item missmatch (-want, +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"custId", "CustomerID"}, aliases); diff != "" {
+		t.Errorf("aliases missmatch (-want, +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,68 @@
+// Command fuelgen generates MarshalDynamoDBItem and UnmarshalDynamoDBItem methods
+// for struct types, so that fuel can encode and decode them without reflection.
+//
+// It is meant to be run by go generate, from the directory of the package declaring the types:
+//
+//	//go:generate fuelgen -type User,Order
+//
+// By default the methods are written to <first type>_fuel.go. With -test, fuelgen also writes
+// a test file that checks the generated methods against fuel's reflection-based encoding.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/shuymn/fuel/fuelgen"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("fuelgen: ")
+
+	typeNames := flag.String("type", "", "comma-separated list of struct type names; required")
+	output := flag.String("output", "", "output file name; default <dir>/<type>_fuel.go")
+	test := flag.Bool("test", false, "also write a test checking the generated methods against reflection")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: fuelgen -type T[,T...] [-output file] [-test] [dir]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if *typeNames == "" || flag.NArg() > 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	dir := "."
+	if flag.NArg() == 1 {
+		dir = flag.Arg(0)
+	}
+	types := strings.Split(*typeNames, ",")
+	if *output == "" {
+		*output = filepath.Join(dir, strings.ToLower(types[0])+"_fuel.go")
+	}
+
+	src, err := fuelgen.Generate(dir, types...)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile(*output, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+
+	if *test {
+		src, err := fuelgen.GenerateTest(dir, types...)
+		if err != nil {
+			log.Fatal(err)
+		}
+		name := strings.TrimSuffix(*output, ".go") + "_test.go"
+		if err := ioutil.WriteFile(name, src, 0o644); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,220 @@
+package fuel
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// Condition is a condition expression, for use as a ConditionExpression or FilterExpression.
+// Build conditions with functions such as Equal and AttributeExists, and combine them
+// with And, Or and Not. The zero Condition is empty and renders as "".
+type Condition struct {
+	op string
+	// for comparisons and functions
+	operands []Operand
+	// for AND, OR and NOT
+	conds []Condition
+	// for templates, the text around the operands
+	template []string
+	// set if the condition is invalid, reported when rendering
+	err error
+}
+
+// condition operators that aren't comparisons
+const (
+	condAnd     = "AND"
+	condOr      = "OR"
+	condNot     = "NOT"
+	condBetween = "BETWEEN"
+	condIn      = "IN"
+)
+
+// IsZero reports whether c is the empty condition.
+func (c Condition) IsZero() bool {
+	return c.op == "" && c.err == nil
+}
+
+func compare(op string, left Operand, right interface{}) Condition {
+	return Condition{op: op, operands: []Operand{left, toOperand(right)}}
+}
+
+// Equal is the condition left = right.
+func Equal(left Operand, right interface{}) Condition {
+	return compare("=", left, right)
+}
+
+// NotEqual is the condition left <> right.
+func NotEqual(left Operand, right interface{}) Condition {
+	return compare("<>", left, right)
+}
+
+// LessThan is the condition left < right.
+func LessThan(left Operand, right interface{}) Condition {
+	return compare("<", left, right)
+}
+
+// LessThanEqual is the condition left <= right.
+func LessThanEqual(left Operand, right interface{}) Condition {
+	return compare("<=", left, right)
+}
+
+// GreaterThan is the condition left > right.
+func GreaterThan(left Operand, right interface{}) Condition {
+	return compare(">", left, right)
+}
+
+// GreaterThanEqual is the condition left >= right.
+func GreaterThanEqual(left Operand, right interface{}) Condition {
+	return compare(">=", left, right)
+}
+
+// Between is the condition op BETWEEN low AND high.
+func Between(op Operand, low, high interface{}) Condition {
+	return Condition{op: condBetween, operands: []Operand{op, toOperand(low), toOperand(high)}}
+}
+
+// In is the condition op IN (candidates...). DynamoDB accepts up to 100 candidates.
+func In(op Operand, candidates ...interface{}) Condition {
+	if len(candidates) == 0 {
+		return Condition{err: fmt.Errorf("dynamodb: condition: IN needs at least one candidate")}
+	}
+	operands := []Operand{op}
+	for _, c := range candidates {
+		operands = append(operands, toOperand(c))
+	}
+	return Condition{op: condIn, operands: operands}
+}
+
+// AttributeExists is the condition attribute_exists(path).
+func AttributeExists(path string) Condition {
+	return Condition{op: "attribute_exists", operands: []Operand{Name(path)}}
+}
+
+// AttributeNotExists is the condition attribute_not_exists(path).
+func AttributeNotExists(path string) Condition {
+	return Condition{op: "attribute_not_exists", operands: []Operand{Name(path)}}
+}
+
+// HasAttributeType is the condition attribute_type(path, t).
+func HasAttributeType(path string, t AttributeType) Condition {
+	switch t {
+	case StringType, NumberType, BinaryType, BoolType, NullType, ListType, MapType, StringSetType, NumberSetType, BinarySetType:
+	default:
+		return Condition{err: fmt.Errorf("dynamodb: condition: invalid attribute type %q", t)}
+	}
+	typ := &types.AttributeValueMemberS{Value: string(t)}
+	return Condition{op: "attribute_type", operands: []Operand{Name(path), Value(typ)}}
+}
+
+// BeginsWith is the condition begins_with(path, prefix).
+func BeginsWith(path string, prefix string) Condition {
+	return Condition{op: "begins_with", operands: []Operand{Name(path), Value(prefix)}}
+}
+
+// Contains is the condition contains(path, v): path is a string containing the substring v,
+// or a set or list containing the element v.
+func Contains(path string, v interface{}) Condition {
+	return Condition{op: "contains", operands: []Operand{Name(path), toOperand(v)}}
+}
+
+// And is the condition that holds if all of conds hold. Empty conditions are ignored.
+func And(conds ...Condition) Condition {
+	return logical(condAnd, conds)
+}
+
+// Or is the condition that holds if any of conds holds. Empty conditions are ignored.
+func Or(conds ...Condition) Condition {
+	return logical(condOr, conds)
+}
+
+func logical(op string, conds []Condition) Condition {
+	var nonEmpty []Condition
+	for _, c := range conds {
+		if !c.IsZero() {
+			nonEmpty = append(nonEmpty, c)
+		}
+	}
+	switch len(nonEmpty) {
+	case 0:
+		return Condition{}
+	case 1:
+		return nonEmpty[0]
+	}
+	return Condition{op: op, conds: nonEmpty}
+}
+
+// Not is the condition that holds if c doesn't.
+func Not(c Condition) Condition {
+	if c.IsZero() {
+		return c
+	}
+	return Condition{op: condNot, conds: []Condition{c}}
+}
+
+// And is the condition that holds if c and all of conds hold.
+func (c Condition) And(conds ...Condition) Condition {
+	return And(append([]Condition{c}, conds...)...)
+}
+
+// Or is the condition that holds if c or any of conds holds.
+func (c Condition) Or(conds ...Condition) Condition {
+	return Or(append([]Condition{c}, conds...)...)
+}
+
+// Render returns the condition expression, adding the names and values it uses to p.
+func (c Condition) Render(p *Placeholders) (string, error) {
+	if c.err != nil {
+		return "", c.err
+	}
+
+	switch c.op {
+	case "":
+		return "", nil
+	case condAnd, condOr:
+		parts := make([]string, 0, len(c.conds))
+		for _, sub := range c.conds {
+			expr, err := sub.Render(p)
+			if err != nil {
+				return "", err
+			}
+			if sub.op == condAnd || sub.op == condOr || sub.op == condTemplate {
+				expr = "(" + expr + ")"
+			}
+			parts = append(parts, expr)
+		}
+		return strings.Join(parts, " "+c.op+" "), nil
+	case condNot:
+		expr, err := c.conds[0].Render(p)
+		if err != nil {
+			return "", err
+		}
+		return "NOT (" + expr + ")", nil
+	}
+
+	operands := make([]string, 0, len(c.operands))
+	for _, op := range c.operands {
+		expr, err := op.render(p)
+		if err != nil {
+			return "", err
+		}
+		operands = append(operands, expr)
+	}
+	switch c.op {
+	case condTemplate:
+		var b strings.Builder
+		for i, op := range operands {
+			b.WriteString(c.template[i] + op)
+		}
+		b.WriteString(c.template[len(operands)])
+		return b.String(), nil
+	case "=", "<>", "<", "<=", ">", ">=":
+		return operands[0] + " " + c.op + " " + operands[1], nil
+	case condBetween:
+		return operands[0] + " BETWEEN " + operands[1] + " AND " + operands[2], nil
+	case condIn:
+		return operands[0] + " IN (" + strings.Join(operands[1:], ", ") + ")", nil
+	}
+	return c.op + "(" + strings.Join(operands, ", ") + ")", nil
+}
@@ -0,0 +1,147 @@
+package fuel
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestConditionRender(t *testing.T) {
+	tests := []struct {
+		name       string
+		cond       Condition
+		expr       string
+		names      map[string]string
+		values     map[string]types.AttributeValue
+		shouldFail bool
+	}{
+		{
+			name: "empty",
+			cond: Condition{},
+			expr: "",
+		},
+		{
+			name:   "equal",
+			cond:   Equal(Name("Status"), "active"),
+			expr:   "#n0 = :v0",
+			names:  map[string]string{"#n0": "Status"},
+			values: map[string]types.AttributeValue{":v0": &types.AttributeValueMemberS{Value: "active"}},
+		},
+		{
+			name:  "compare names",
+			cond:  GreaterThan(Name("Balance"), Name("Limit")),
+			expr:  "Balance > #n0",
+			names: map[string]string{"#n0": "Limit"},
+		},
+		{
+			name:   "size and document path",
+			cond:   LessThanEqual(Size("orders[2].lines"), 10),
+			expr:   "size(orders[2].#n0) <= :v0",
+			names:  map[string]string{"#n0": "lines"},
+			values: map[string]types.AttributeValue{":v0": &types.AttributeValueMemberN{Value: "10"}},
+		},
+		{
+			name: "empty string value",
+			cond: NotEqual(Name("Note"), ""),
+			expr: "Note <> :v0",
+			values: map[string]types.AttributeValue{
+				":v0": &types.AttributeValueMemberS{Value: ""},
+			},
+		},
+		{
+			name:  "between",
+			cond:  Between(Name("Count"), 1, 5),
+			expr:  "#n0 BETWEEN :v0 AND :v1",
+			names: map[string]string{"#n0": "Count"},
+			values: map[string]types.AttributeValue{
+				":v0": &types.AttributeValueMemberN{Value: "1"},
+				":v1": &types.AttributeValueMemberN{Value: "5"},
+			},
+		},
+		{
+			name: "in",
+			cond: In(Name("Color"), "red", "blue"),
+			expr: "Color IN (:v0, :v1)",
+			values: map[string]types.AttributeValue{
+				":v0": &types.AttributeValueMemberS{Value: "red"},
+				":v1": &types.AttributeValueMemberS{Value: "blue"},
+			},
+		},
+		{
+			name: "functions",
+			cond: And(AttributeExists("ID"), AttributeNotExists("Deleted"), HasAttributeType("Tags", StringSetType)),
+			expr: "attribute_exists(ID) AND attribute_not_exists(Deleted) AND attribute_type(Tags, :v0)",
+			values: map[string]types.AttributeValue{
+				":v0": &types.AttributeValueMemberS{Value: "SS"},
+			},
+		},
+		{
+			name: "begins_with and contains",
+			cond: BeginsWith("SK", "ORDER#").Or(Contains("Tags", "new")),
+			expr: "begins_with(SK, :v0) OR contains(Tags, :v1)",
+			values: map[string]types.AttributeValue{
+				":v0": &types.AttributeValueMemberS{Value: "ORDER#"},
+				":v1": &types.AttributeValueMemberS{Value: "new"},
+			},
+		},
+		{
+			name:  "nesting and reused names",
+			cond:  Not(Or(Equal(Name("Status"), true), And(Equal(Name("Status"), false), Equal(Name("Data"), nil)))),
+			expr:  "NOT (#n0 = :v0 OR (#n0 = :v1 AND #n1 = :v2))",
+			names: map[string]string{"#n0": "Status", "#n1": "Data"},
+			values: map[string]types.AttributeValue{
+				":v0": &types.AttributeValueMemberBOOL{Value: true},
+				":v1": &types.AttributeValueMemberBOOL{Value: false},
+				":v2": &types.AttributeValueMemberNULL{Value: true},
+			},
+		},
+		{
+			name: "empty conditions are ignored",
+			cond: And(Condition{}, Equal(Name("A"), 1), Not(Condition{})),
+			expr: "A = :v0",
+			values: map[string]types.AttributeValue{
+				":v0": &types.AttributeValueMemberN{Value: "1"},
+			},
+		},
+		{
+			name:       "invalid path",
+			cond:       AttributeExists("a..b"),
+			shouldFail: true,
+		},
+		{
+			name:       "invalid attribute type",
+			cond:       HasAttributeType("a", AnyType),
+			shouldFail: true,
+		},
+		{
+			name:       "in without candidates",
+			cond:       In(Name("a")),
+			shouldFail: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p Placeholders
+			expr, err := tt.cond.Render(&p)
+			if tt.shouldFail {
+				if err == nil {
+					t.Fatalf("expected error, got %q", expr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if expr != tt.expr {
+				t.Errorf("expression missmatch: want %q, got %q", tt.expr, expr)
+			}
+			if diff := cmp.Diff(tt.names, p.Names); diff != "" {
+				t.Errorf("names missmatch (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.values, p.Values); diff != "" {
+				t.Errorf("values missmatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
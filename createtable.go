@@ -0,0 +1,308 @@
+package fuel
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// CreateTableInput builds the input for creating a table for items of struct type t,
+// from the key options in its tags:
+//
+//	ID      string    `dynamodb:"id,hash"`                      // table partition key
+//	Created time.Time `dynamodb:"created,range"`                // table sort key
+//	Email   string    `dynamodb:"email,index=ByEmail,hash"`     // global secondary index partition key
+//	Score   int       `dynamodb:",lsi=ByScore,range"`           // local secondary index sort key
+//	Owner   string    `dynamodb:",index=ByOwner,hash,projection=KEYS_ONLY"`
+//	Expires time.Time `dynamodb:",unixtime,ttl"`                // time to live attribute
+//
+// A hash or range option right after an index= or lsi= option applies to that index,
+// otherwise it applies to the table. Global indexes default to hash and local indexes to range.
+// projection= applies to the preceding index and is one of ALL (the default), KEYS_ONLY
+// or INCLUDE:attr1|attr2.
+//
+// Key attribute types are inferred from the fields, which must encode as S, N or B.
+// The table uses on-demand billing. If t has a ttl field, the returned
+// UpdateTimeToLiveInput enables it; otherwise it is nil.
+func CreateTableInput(tableName string, t reflect.Type) (*dynamodb.CreateTableInput, *dynamodb.UpdateTimeToLiveInput, error) {
+	return defaultEncoder.CreateTableInput(tableName, t)
+}
+
+type tableIndex struct {
+	name       string
+	local      bool
+	hash       string
+	rangeKey   string
+	projection *types.Projection
+}
+
+// CreateTableInput builds the input for creating a table for items of struct type t
+func (e *Encoder) CreateTableInput(tableName string, t reflect.Type) (*dynamodb.CreateTableInput, *dynamodb.UpdateTimeToLiveInput, error) {
+	t = derefType(t)
+	if t.Kind() != reflect.Struct {
+		return nil, nil, fmt.Errorf("dynamodb: create table: not a struct: %s", t)
+	}
+	keys, err := cachedTableKeys(t, e.fieldOpts)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !keys.declared() {
+		return nil, nil, fmt.Errorf("dynamodb: create table: %s has no hash key", t)
+	}
+
+	var attrs []types.AttributeDefinition
+	for _, f := range keys.keyFields {
+		var st types.ScalarAttributeType
+		switch e.attributeType(t.FieldByIndex(f.index).Type, f.flags) {
+		case StringType:
+			st = types.ScalarAttributeTypeS
+		case NumberType:
+			st = types.ScalarAttributeTypeN
+		case BinaryType:
+			st = types.ScalarAttributeTypeB
+		default:
+			return nil, nil, fmt.Errorf("dynamodb: create table: key attribute %s must be a string, number or binary", f.name)
+		}
+		attrs = append(attrs, types.AttributeDefinition{
+			AttributeName: aws.String(f.name),
+			AttributeType: st,
+		})
+	}
+	if keys.ttl != nil {
+		if at := e.attributeType(t.FieldByIndex(keys.ttl.index).Type, keys.ttl.flags); at != NumberType {
+			return nil, nil, fmt.Errorf("dynamodb: create table: ttl attribute %s must be a number (use unixtime for time.Time)", keys.ttl.name)
+		}
+	}
+
+	input := &dynamodb.CreateTableInput{
+		TableName:            aws.String(tableName),
+		AttributeDefinitions: attrs,
+		KeySchema:            keySchema(keys.hash, keys.rangeKey),
+		BillingMode:          types.BillingModePayPerRequest,
+	}
+	for _, idx := range keys.indexes {
+		proj := idx.projection
+		if proj == nil {
+			proj = &types.Projection{ProjectionType: types.ProjectionTypeAll}
+		}
+		if idx.local {
+			input.LocalSecondaryIndexes = append(input.LocalSecondaryIndexes, types.LocalSecondaryIndex{
+				IndexName:  aws.String(idx.name),
+				KeySchema:  keySchema(keys.hash, idx.rangeKey),
+				Projection: proj,
+			})
+			continue
+		}
+		input.GlobalSecondaryIndexes = append(input.GlobalSecondaryIndexes, types.GlobalSecondaryIndex{
+			IndexName:  aws.String(idx.name),
+			KeySchema:  keySchema(idx.hash, idx.rangeKey),
+			Projection: proj,
+		})
+	}
+
+	var ttlInput *dynamodb.UpdateTimeToLiveInput
+	if keys.ttl != nil {
+		ttlInput = &dynamodb.UpdateTimeToLiveInput{
+			TableName: aws.String(tableName),
+			TimeToLiveSpecification: &types.TimeToLiveSpecification{
+				AttributeName: aws.String(keys.ttl.name),
+				Enabled:       aws.Bool(true),
+			},
+		}
+	}
+	return input, ttlInput, nil
+}
+
+// tableKeys is the layout of a table and its indexes, as declared by the key options
+// of a struct type's tags
+type tableKeys struct {
+	hash     string
+	rangeKey string
+	indexes  []*tableIndex
+	// fields used as keys of the table or an index, in declaration order
+	keyFields []field
+	ttl       *field
+	err       error
+}
+
+// declared reports whether the type has any key options
+func (k *tableKeys) declared() bool {
+	return k.hash != "" || k.rangeKey != "" || len(k.indexes) > 0
+}
+
+var tableKeysCache sync.Map // map[fieldsKey]*tableKeys
+
+// cachedTableKeys returns the table layout of struct type t, caching it like cachedFields.
+// A type without key options has an empty layout; malformed options are an error.
+func cachedTableKeys(t reflect.Type, opts fieldOptions) (*tableKeys, error) {
+	key := fieldsKey{rt: t, opts: opts}
+	if k, ok := tableKeysCache.Load(key); ok {
+		return k.(*tableKeys), k.(*tableKeys).err
+	}
+	fields, err := cachedFields(t, opts)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := parseTableKeys(t, fields)
+	if err != nil {
+		keys = &tableKeys{err: err}
+	}
+	k, _ := tableKeysCache.LoadOrStore(key, keys)
+	return k.(*tableKeys), k.(*tableKeys).err
+}
+
+// parseTableKeys reads the key options of the fields of t
+func parseTableKeys(t reflect.Type, fields *structFields) (*tableKeys, error) {
+	keys := &tableKeys{}
+	byName := make(map[string]*tableIndex)
+	used := make(map[string]bool)
+
+	useKey := func(f field) {
+		if !used[f.name] {
+			used[f.name] = true
+			keys.keyFields = append(keys.keyFields, f)
+		}
+	}
+	setKey := func(dst *string, what, name string) error {
+		if *dst != "" && *dst != name {
+			return fmt.Errorf("dynamodb: create table: %s declared twice (%s and %s)", what, *dst, name)
+		}
+		*dst = name
+		return nil
+	}
+
+	for _, f := range fields.list {
+		f := f
+		tags := strings.Split(t.FieldByIndex(f.index).Tag.Get("dynamodb"), ",")
+
+		var cur *tableIndex // the most recent index option
+		open := false       // whether the preceding option was an index
+		for _, opt := range tags[1:] {
+			var err error
+			switch {
+			case opt == "hash" || opt == "range":
+				useKey(f)
+				switch {
+				case open && opt == "hash":
+					if cur.local {
+						return nil, fmt.Errorf("dynamodb: create table: local index %s can't have a hash key", cur.name)
+					}
+					err = setKey(&cur.hash, "hash key of index "+cur.name, f.name)
+				case open:
+					err = setKey(&cur.rangeKey, "range key of index "+cur.name, f.name)
+				case opt == "hash":
+					err = setKey(&keys.hash, "table hash key", f.name)
+				default:
+					err = setKey(&keys.rangeKey, "table range key", f.name)
+				}
+				open = false
+			case strings.HasPrefix(opt, "index=") || strings.HasPrefix(opt, "lsi="):
+				if open {
+					if err := cur.setDefaultKey(f.name); err != nil {
+						return nil, err
+					}
+				}
+				local := strings.HasPrefix(opt, "lsi=")
+				name := opt[strings.IndexByte(opt, '=')+1:]
+				idx, ok := byName[name]
+				if !ok {
+					idx = &tableIndex{name: name, local: local}
+					byName[name] = idx
+					keys.indexes = append(keys.indexes, idx)
+				} else if idx.local != local {
+					return nil, fmt.Errorf("dynamodb: create table: index %s declared as both global and local", name)
+				}
+				useKey(f)
+				cur, open = idx, true
+			case strings.HasPrefix(opt, "projection="):
+				if cur == nil {
+					return nil, fmt.Errorf("dynamodb: create table: %s: projection without index", f.name)
+				}
+				if cur.projection, err = parseProjection(opt[len("projection="):]); err != nil {
+					return nil, err
+				}
+				if open {
+					err = cur.setDefaultKey(f.name)
+				}
+				open = false
+			case opt == "ttl":
+				if keys.ttl != nil && keys.ttl.name != f.name {
+					return nil, fmt.Errorf("dynamodb: create table: ttl attribute declared twice (%s and %s)", keys.ttl.name, f.name)
+				}
+				keys.ttl = &f
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		if open {
+			if err := cur.setDefaultKey(f.name); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if !keys.declared() {
+		return keys, nil
+	}
+	if keys.hash == "" {
+		return nil, fmt.Errorf("dynamodb: create table: %s has no hash key", t)
+	}
+	for _, idx := range keys.indexes {
+		if idx.local && idx.rangeKey == "" {
+			return nil, fmt.Errorf("dynamodb: create table: local index %s has no range key", idx.name)
+		}
+		if !idx.local && idx.hash == "" {
+			return nil, fmt.Errorf("dynamodb: create table: index %s has no hash key", idx.name)
+		}
+	}
+	return keys, nil
+}
+
+// setDefaultKey sets the key for an index option without an explicit hash or range option
+func (idx *tableIndex) setDefaultKey(name string) error {
+	dst, what := &idx.hash, "hash key"
+	if idx.local {
+		dst, what = &idx.rangeKey, "range key"
+	}
+	if *dst != "" && *dst != name {
+		return fmt.Errorf("dynamodb: create table: %s of index %s declared twice (%s and %s)", what, idx.name, *dst, name)
+	}
+	*dst = name
+	return nil
+}
+
+func keySchema(hash, rangeKey string) []types.KeySchemaElement {
+	schema := []types.KeySchemaElement{{
+		AttributeName: aws.String(hash),
+		KeyType:       types.KeyTypeHash,
+	}}
+	if rangeKey != "" {
+		schema = append(schema, types.KeySchemaElement{
+			AttributeName: aws.String(rangeKey),
+			KeyType:       types.KeyTypeRange,
+		})
+	}
+	return schema
+}
+
+func parseProjection(s string) (*types.Projection, error) {
+	switch {
+	case s == string(types.ProjectionTypeAll):
+		return &types.Projection{ProjectionType: types.ProjectionTypeAll}, nil
+	case s == string(types.ProjectionTypeKeysOnly):
+		return &types.Projection{ProjectionType: types.ProjectionTypeKeysOnly}, nil
+	case strings.HasPrefix(s, string(types.ProjectionTypeInclude)+":"):
+		attrs := strings.Split(s[len(types.ProjectionTypeInclude)+1:], "|")
+		return &types.Projection{
+			ProjectionType:   types.ProjectionTypeInclude,
+			NonKeyAttributes: attrs,
+		}, nil
+	}
+	return nil, fmt.Errorf("dynamodb: create table: invalid projection %q", s)
+}
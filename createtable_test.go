@@ -0,0 +1,151 @@
+package fuel
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+type tableItem struct {
+	ID      string    `dynamodb:"id,hash"`
+	Created time.Time `dynamodb:"created,range,index=ByOwner,range"`
+	Owner   string    `dynamodb:",index=ByOwner,projection=INCLUDE:Title|Body"`
+	Score   int       `dynamodb:",lsi=ByScore"`
+	Data    []byte    `dynamodb:",index=ByData,hash,projection=KEYS_ONLY"`
+	Expires time.Time `dynamodb:",unixtime,ttl"`
+	Title   string
+}
+
+func TestCreateTableInput(t *testing.T) {
+	input, ttl, err := CreateTableInput("Items", reflect.TypeOf(tableItem{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &dynamodb.CreateTableInput{
+		TableName:   aws.String("Items"),
+		BillingMode: types.BillingModePayPerRequest,
+		AttributeDefinitions: []types.AttributeDefinition{
+			{AttributeName: aws.String("id"), AttributeType: types.ScalarAttributeTypeS},
+			{AttributeName: aws.String("created"), AttributeType: types.ScalarAttributeTypeS},
+			{AttributeName: aws.String("Owner"), AttributeType: types.ScalarAttributeTypeS},
+			{AttributeName: aws.String("Score"), AttributeType: types.ScalarAttributeTypeN},
+			{AttributeName: aws.String("Data"), AttributeType: types.ScalarAttributeTypeB},
+		},
+		KeySchema: []types.KeySchemaElement{
+			{AttributeName: aws.String("id"), KeyType: types.KeyTypeHash},
+			{AttributeName: aws.String("created"), KeyType: types.KeyTypeRange},
+		},
+		GlobalSecondaryIndexes: []types.GlobalSecondaryIndex{
+			{
+				IndexName: aws.String("ByOwner"),
+				KeySchema: []types.KeySchemaElement{
+					{AttributeName: aws.String("Owner"), KeyType: types.KeyTypeHash},
+					{AttributeName: aws.String("created"), KeyType: types.KeyTypeRange},
+				},
+				Projection: &types.Projection{
+					ProjectionType:   types.ProjectionTypeInclude,
+					NonKeyAttributes: []string{"Title", "Body"},
+				},
+			},
+			{
+				IndexName: aws.String("ByData"),
+				KeySchema: []types.KeySchemaElement{
+					{AttributeName: aws.String("Data"), KeyType: types.KeyTypeHash},
+				},
+				Projection: &types.Projection{ProjectionType: types.ProjectionTypeKeysOnly},
+			},
+		},
+		LocalSecondaryIndexes: []types.LocalSecondaryIndex{
+			{
+				IndexName: aws.String("ByScore"),
+				KeySchema: []types.KeySchemaElement{
+					{AttributeName: aws.String("id"), KeyType: types.KeyTypeHash},
+					{AttributeName: aws.String("Score"), KeyType: types.KeyTypeRange},
+				},
+				Projection: &types.Projection{ProjectionType: types.ProjectionTypeAll},
+			},
+		},
+	}
+	opt := cmpopts.IgnoreUnexported(
+		dynamodb.CreateTableInput{},
+		types.AttributeDefinition{},
+		types.KeySchemaElement{},
+		types.GlobalSecondaryIndex{},
+		types.LocalSecondaryIndex{},
+		types.Projection{},
+	)
+	if diff := cmp.Diff(want, input, opt); diff != "" {
+		t.Errorf("missmatch (-want, +got):\n%s", diff)
+	}
+
+	if ttl == nil || aws.ToString(ttl.TimeToLiveSpecification.AttributeName) != "Expires" || !aws.ToBool(ttl.TimeToLiveSpecification.Enabled) {
+		t.Errorf("bad ttl input: %+v", ttl)
+	}
+}
+
+func TestCreateTableInputErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		in   interface{}
+	}{
+		{
+			name: "no hash key",
+			in: struct {
+				ID string `dynamodb:",range"`
+			}{},
+		},
+		{
+			name: "two hash keys",
+			in: struct {
+				A string `dynamodb:",hash"`
+				B string `dynamodb:",hash"`
+			}{},
+		},
+		{
+			name: "bad key type",
+			in: struct {
+				A []string `dynamodb:",hash"`
+			}{},
+		},
+		{
+			name: "local index hash key",
+			in: struct {
+				A string `dynamodb:",hash"`
+				B string `dynamodb:",lsi=Local,hash"`
+			}{},
+		},
+		{
+			name: "bad projection",
+			in: struct {
+				A string `dynamodb:",hash,index=GSI,projection=SOME"`
+			}{},
+		},
+		{
+			name: "bad ttl",
+			in: struct {
+				A string    `dynamodb:",hash"`
+				T time.Time `dynamodb:",ttl"`
+			}{},
+		},
+	}
+
+	for _, tc := range tests {
+		if _, _, err := CreateTableInput("T", reflect.TypeOf(tc.in)); err == nil {
+			t.Errorf("%s: expected error, got nil", tc.name)
+		}
+	}
+
+	input, ttl, err := CreateTableInput("T", reflect.TypeOf(struct {
+		A string `dynamodb:",hash"`
+	}{}))
+	if err != nil || ttl != nil || len(input.KeySchema) != 1 {
+		t.Errorf("hash only: unexpected result %+v, %+v, %v", input, ttl, err)
+	}
+}
@@ -0,0 +1,287 @@
+package fuel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// ErrNotFound is returned when the requested item doesn't exist.
+var ErrNotFound = errors.New("dynamodb: item not found")
+
+// Client is the part of the DynamoDB API used by DB. *dynamodb.Client implements it;
+// tests can use a fake instead.
+type Client interface {
+	PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error)
+	GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error)
+	DeleteItem(ctx context.Context, params *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error)
+	Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error)
+	Scan(ctx context.Context, params *dynamodb.ScanInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error)
+}
+
+var _ Client = (*dynamodb.Client)(nil)
+
+// DB reads and writes items through a Client, encoding and decoding them with fuel.
+type DB struct {
+	client Client
+	enc    *Encoder
+	dec    *Decoder
+}
+
+// DBOption configures a DB.
+type DBOption func(*DB)
+
+// UseEncoder makes the DB encode items with e instead of the default Encoder.
+// Values in conditions and key conditions, and the types of projections, are encoded with it too.
+func UseEncoder(e *Encoder) DBOption {
+	return func(db *DB) {
+		db.enc = e
+	}
+}
+
+// UseDecoder makes the DB decode items with d instead of the default Decoder.
+func UseDecoder(d *Decoder) DBOption {
+	return func(db *DB) {
+		db.dec = d
+	}
+}
+
+// NewDB returns a DB using client, such as a *dynamodb.Client.
+func NewDB(client Client, opts ...DBOption) *DB {
+	db := &DB{client: client, enc: defaultEncoder, dec: defaultDecoder}
+	for _, opt := range opts {
+		opt(db)
+	}
+	return db
+}
+
+// Table returns a handle for the table name.
+func (db *DB) Table(name string) *Table {
+	return &Table{db: db, name: name}
+}
+
+// Table reads and writes the items of a table.
+type Table struct {
+	db   *DB
+	name string
+}
+
+// Name returns the name of the table.
+func (t *Table) Name() string {
+	return t.name
+}
+
+// ReadOption configures a read.
+type ReadOption func(*readOptions)
+
+type readOptions struct {
+	consistent bool
+	projection Projection
+}
+
+// ConsistentRead makes a read strongly consistent.
+func ConsistentRead() ReadOption {
+	return func(o *readOptions) {
+		o.consistent = true
+	}
+}
+
+// Projecting makes a read fetch only the attributes of pr.
+func Projecting(pr Projection) ReadOption {
+	return func(o *readOptions) {
+		o.projection = pr
+	}
+}
+
+// WriteOption configures a write.
+type WriteOption func(*writeOptions)
+
+type writeOptions struct {
+	cond Condition
+	old  interface{}
+}
+
+// If makes a write happen only if cond holds for the item being written.
+// Values in cond are encoded with the DB's Encoder.
+// Otherwise the write fails with a *types.ConditionalCheckFailedException.
+func If(cond Condition) WriteOption {
+	return func(o *writeOptions) {
+		o.cond = o.cond.And(cond)
+	}
+}
+
+// ReturnOld decodes the item as it was before a write into out, with UnmarshalItem.
+// If there was no such item, out is left as it is.
+func ReturnOld(out interface{}) WriteOption {
+	return func(o *writeOptions) {
+		o.old = out
+	}
+}
+
+func readOpts(opts []ReadOption) readOptions {
+	var o readOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// placeholders returns the Placeholders for a request, which encode values like items
+func (t *Table) placeholders() *Placeholders {
+	return &Placeholders{Encoder: t.db.enc}
+}
+
+// writeOpts applies opts and renders their condition
+func (t *Table) writeOpts(opts []WriteOption) (writeOptions, *string, *Placeholders, error) {
+	var o writeOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	p := t.placeholders()
+	cond, err := o.cond.Render(p)
+	if err != nil || cond == "" {
+		return o, nil, p, err
+	}
+	return o, aws.String(cond), p, nil
+}
+
+// Put writes the item v, replacing any item with the same key.
+func (t *Table) Put(ctx context.Context, v interface{}, opts ...WriteOption) error {
+	item, err := t.db.enc.MarshalItem(v)
+	if err != nil {
+		return err
+	}
+	o, cond, p, err := t.writeOpts(opts)
+	if err != nil {
+		return err
+	}
+
+	in := &dynamodb.PutItemInput{
+		TableName:                 aws.String(t.name),
+		Item:                      item,
+		ConditionExpression:       cond,
+		ExpressionAttributeNames:  p.Names,
+		ExpressionAttributeValues: p.Values,
+	}
+	if o.old != nil {
+		in.ReturnValues = types.ReturnValueAllOld
+	}
+	out, err := t.db.client.PutItem(ctx, in)
+	if err != nil {
+		return err
+	}
+	return t.decodeOld(out.Attributes, o.old)
+}
+
+// Get reads the item with the given key into out, with UnmarshalItem.
+// If there is no such item, it returns ErrNotFound.
+//
+// key is a map of the key attributes, or a struct (or a pointer to one): if its type has
+// table hash and range options (see CreateTableInput), only those attributes are used;
+// a struct without key options is used as a whole, and malformed options are an error.
+func (t *Table) Get(ctx context.Context, key interface{}, out interface{}, opts ...ReadOption) error {
+	keyItem, err := t.key(key)
+	if err != nil {
+		return err
+	}
+	o := readOpts(opts)
+	p := t.placeholders()
+	proj, err := o.projection.Render(p)
+	if err != nil {
+		return err
+	}
+
+	in := &dynamodb.GetItemInput{
+		TableName:                aws.String(t.name),
+		Key:                      keyItem,
+		ConsistentRead:           aws.Bool(o.consistent),
+		ExpressionAttributeNames: p.Names,
+	}
+	if proj != "" {
+		in.ProjectionExpression = aws.String(proj)
+	}
+	res, err := t.db.client.GetItem(ctx, in)
+	if err != nil {
+		return err
+	}
+	if res.Item == nil {
+		return ErrNotFound
+	}
+	return t.db.dec.UnmarshalItem(res.Item, out)
+}
+
+// Delete deletes the item with the given key, as in Get. Deleting an item that doesn't exist
+// isn't an error, unless a condition says otherwise.
+func (t *Table) Delete(ctx context.Context, key interface{}, opts ...WriteOption) error {
+	keyItem, err := t.key(key)
+	if err != nil {
+		return err
+	}
+	o, cond, p, err := t.writeOpts(opts)
+	if err != nil {
+		return err
+	}
+
+	in := &dynamodb.DeleteItemInput{
+		TableName:                 aws.String(t.name),
+		Key:                       keyItem,
+		ConditionExpression:       cond,
+		ExpressionAttributeNames:  p.Names,
+		ExpressionAttributeValues: p.Values,
+	}
+	if o.old != nil {
+		in.ReturnValues = types.ReturnValueAllOld
+	}
+	res, err := t.db.client.DeleteItem(ctx, in)
+	if err != nil {
+		return err
+	}
+	return t.decodeOld(res.Attributes, o.old)
+}
+
+func (t *Table) decodeOld(item map[string]types.AttributeValue, out interface{}) error {
+	if out == nil || len(item) == 0 {
+		return nil
+	}
+	return t.db.dec.UnmarshalItem(item, out)
+}
+
+// key encodes the key of an item
+func (t *Table) key(key interface{}) (map[string]types.AttributeValue, error) {
+	if key == nil {
+		return nil, fmt.Errorf("dynamodb: %s: nil key", t.name)
+	}
+	item, err := t.db.enc.marshalItem(key)
+	if err != nil {
+		return nil, err
+	}
+	rt := indirectType(key)
+	if rt.Kind() != reflect.Struct {
+		return item, nil
+	}
+	keys, err := cachedTableKeys(rt, t.db.enc.fieldOpts)
+	if err != nil {
+		return nil, err
+	}
+	if !keys.declared() {
+		// no key options: the struct holds just the key
+		return item, nil
+	}
+	keyItem := make(map[string]types.AttributeValue, 2)
+	for _, name := range []string{keys.hash, keys.rangeKey} {
+		if name == "" {
+			continue
+		}
+		av, ok := item[name]
+		if !ok {
+			return nil, fmt.Errorf("dynamodb: %s: key attribute %s is missing", t.name, name)
+		}
+		keyItem[name] = av
+	}
+	return keyItem, nil
+}
@@ -0,0 +1,347 @@
+package fuel
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+// fakeClient is an in-memory table keyed by the string attribute "id".
+// It records the last input it got and doesn't evaluate expressions, except for the top-level
+// attributes of projections: queries return every item, ordered by id, pageSize items per page.
+type fakeClient struct {
+	mu       sync.Mutex
+	items    map[string]map[string]types.AttributeValue
+	last     interface{}
+	pageSize int
+	requests int
+	// scanErr, if set, can make a scan request of segment from the item after start fail
+	scanErr func(segment int, start string) error
+}
+
+func newFakeClient() *fakeClient {
+	return &fakeClient{items: make(map[string]map[string]types.AttributeValue)}
+}
+
+func fakeID(item map[string]types.AttributeValue) string {
+	s, _ := item["id"].(*types.AttributeValueMemberS)
+	if s == nil {
+		return ""
+	}
+	return s.Value
+}
+
+func (c *fakeClient) PutItem(ctx context.Context, in *dynamodb.PutItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+	c.last = in
+	id := fakeID(in.Item)
+	out := &dynamodb.PutItemOutput{}
+	if in.ReturnValues == types.ReturnValueAllOld {
+		out.Attributes = c.items[id]
+	}
+	c.items[id] = in.Item
+	return out, nil
+}
+
+// fakeProject keeps the top-level attributes of item named in the projection expression
+func fakeProject(item map[string]types.AttributeValue, expr *string, names map[string]string) map[string]types.AttributeValue {
+	if expr == nil || item == nil {
+		return item
+	}
+	projected := make(map[string]types.AttributeValue)
+	for _, path := range strings.Split(*expr, ", ") {
+		name := path
+		if i := strings.IndexAny(path, ".["); i >= 0 {
+			name = path[:i]
+		}
+		if n, ok := names[name]; ok {
+			name = n
+		}
+		if av, ok := item[name]; ok {
+			projected[name] = av
+		}
+	}
+	return projected
+}
+
+func (c *fakeClient) GetItem(ctx context.Context, in *dynamodb.GetItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+	c.last = in
+	return &dynamodb.GetItemOutput{Item: fakeProject(c.items[fakeID(in.Key)], in.ProjectionExpression, in.ExpressionAttributeNames)}, nil
+}
+
+func (c *fakeClient) DeleteItem(ctx context.Context, in *dynamodb.DeleteItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error) {
+	c.last = in
+	id := fakeID(in.Key)
+	out := &dynamodb.DeleteItemOutput{}
+	if in.ReturnValues == types.ReturnValueAllOld {
+		out.Attributes = c.items[id]
+	}
+	delete(c.items, id)
+	return out, nil
+}
+
+func (c *fakeClient) Query(ctx context.Context, in *dynamodb.QueryInput, _ ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+	c.last = in
+	c.requests++
+	ids := make([]string, 0, len(c.items))
+	for id := range c.items {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	if !aws.ToBool(in.ScanIndexForward) {
+		sort.Sort(sort.Reverse(sort.StringSlice(ids)))
+	}
+	if start := fakeID(in.ExclusiveStartKey); start != "" {
+		for i, id := range ids {
+			if id == start {
+				ids = ids[i+1:]
+				break
+			}
+		}
+	}
+
+	n := len(ids)
+	if c.pageSize > 0 && c.pageSize < n {
+		n = c.pageSize
+	}
+	if in.Limit != nil && int(*in.Limit) < n {
+		n = int(*in.Limit)
+	}
+	out := &dynamodb.QueryOutput{}
+	for _, id := range ids[:n] {
+		out.Items = append(out.Items, fakeProject(c.items[id], in.ProjectionExpression, in.ExpressionAttributeNames))
+	}
+	if n < len(ids) {
+		out.LastEvaluatedKey = map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: ids[n-1]}}
+	}
+	return out, nil
+}
+
+func (c *fakeClient) Scan(ctx context.Context, in *dynamodb.ScanInput, _ ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requests++
+	segment, total := int(aws.ToInt32(in.Segment)), int(aws.ToInt32(in.TotalSegments))
+	if total == 0 {
+		total = 1
+	}
+	start := fakeID(in.ExclusiveStartKey)
+	if c.scanErr != nil {
+		if err := c.scanErr(segment, start); err != nil {
+			return nil, err
+		}
+	}
+
+	ids := make([]string, 0, len(c.items))
+	for id := range c.items {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	var segmentIDs []string
+	for i, id := range ids {
+		if i%total == segment && id > start {
+			segmentIDs = append(segmentIDs, id)
+		}
+	}
+
+	n := len(segmentIDs)
+	if c.pageSize > 0 && c.pageSize < n {
+		n = c.pageSize
+	}
+	out := &dynamodb.ScanOutput{}
+	for _, id := range segmentIDs[:n] {
+		out.Items = append(out.Items, fakeProject(c.items[id], in.ProjectionExpression, in.ExpressionAttributeNames))
+	}
+	if n < len(segmentIDs) {
+		out.LastEvaluatedKey = map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: segmentIDs[n-1]}}
+	}
+	return out, nil
+}
+
+type dbItem struct {
+	ID    string `dynamodb:"id,hash"`
+	Name  string
+	Count int
+}
+
+func TestTable(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeClient()
+	table := NewDB(client).Table("items")
+
+	if err := table.Put(ctx, dbItem{ID: "1", Name: "fuel", Count: 1}); err != nil {
+		t.Fatal(err)
+	}
+	var old dbItem
+	err := table.Put(ctx, &dbItem{ID: "1", Name: "fuel", Count: 2}, If(AttributeExists("id")), ReturnOld(&old))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(dbItem{ID: "1", Name: "fuel", Count: 1}, old); diff != "" {
+		t.Errorf("old item missmatch (-want, +got):\n%s", diff)
+	}
+	put := client.last.(*dynamodb.PutItemInput)
+	if aws.ToString(put.ConditionExpression) != "attribute_exists(id)" || put.ReturnValues != types.ReturnValueAllOld {
+		t.Errorf("bad put input: %+v", put)
+	}
+
+	// a full item works as a key
+	var got dbItem
+	if err := table.Get(ctx, dbItem{ID: "1", Name: "ignored"}, &got, ConsistentRead(), Projecting(ProjectPaths("id", "Name", "Count"))); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(dbItem{ID: "1", Name: "fuel", Count: 2}, got); diff != "" {
+		t.Errorf("item missmatch (-want, +got):\n%s", diff)
+	}
+	get := client.last.(*dynamodb.GetItemInput)
+	if !aws.ToBool(get.ConsistentRead) || len(get.Key) != 1 || aws.ToString(get.ProjectionExpression) != "id, #n0, #n1" {
+		t.Errorf("bad get input: %+v", get)
+	}
+
+	old = dbItem{}
+	if err := table.Delete(ctx, map[string]string{"id": "1"}, If(Equal(Name("Count"), 2)), ReturnOld(&old)); err != nil {
+		t.Fatal(err)
+	}
+	if old.Count != 2 {
+		t.Errorf("old item missmatch: %+v", old)
+	}
+	del := client.last.(*dynamodb.DeleteItemInput)
+	if aws.ToString(del.ConditionExpression) != "#n0 = :v0" || del.ExpressionAttributeNames["#n0"] != "Count" {
+		t.Errorf("bad delete input: %+v", del)
+	}
+
+	if err := table.Get(ctx, map[string]string{"id": "1"}, &got); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+	if err := table.Get(ctx, dbItem{Name: "no id"}, &got); err == nil {
+		t.Error("missing key: expected error, got nil")
+	}
+	if err := table.Put(ctx, dbItem{ID: "2"}, If(In(Name("x")))); err == nil {
+		t.Error("invalid condition: expected error, got nil")
+	}
+}
+
+// migratedRecord is stored with the version attribute, which projections must fetch
+type migratedRecord struct {
+	ID   string `dynamodb:"id,hash"`
+	Name string
+}
+
+func init() {
+	RegisterMigration(reflect.TypeOf(migratedRecord{}), 0, 1, func(item map[string]types.AttributeValue) (map[string]types.AttributeValue, error) {
+		item["Name"] = &types.AttributeValueMemberS{Value: "migrated twice"}
+		return item, nil
+	})
+}
+
+func TestTableGetProjectedMigrated(t *testing.T) {
+	ctx := context.Background()
+	table := NewDB(newFakeClient()).Table("items")
+
+	want := migratedRecord{ID: "1", Name: "current"}
+	if err := table.Put(ctx, want); err != nil {
+		t.Fatal(err)
+	}
+	var got migratedRecord
+	if err := table.Get(ctx, want, &got, Projecting(Project(got))); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("missmatch (-want, +got):\n%s", diff)
+	}
+}
+
+// shoutID is encoded in upper case by shoutEncoder
+type shoutID string
+
+var shoutEncoder = NewEncoder(UseEncodeHook(reflect.TypeOf(shoutID("")), func(v interface{}) (types.AttributeValue, error) {
+	return &types.AttributeValueMemberS{Value: strings.ToUpper(string(v.(shoutID)))}, nil
+}), NameFields(SnakeCaseNames))
+
+func TestTableUsesEncoder(t *testing.T) {
+	type shoutItem struct {
+		ID        shoutID `dynamodb:"id,hash"`
+		ItemCount int
+	}
+	ctx := context.Background()
+	client := newFakeClient()
+	table := NewDB(client, UseEncoder(shoutEncoder), UseDecoder(NewDecoder(NameFields(SnakeCaseNames)))).Table("items")
+	want := &types.AttributeValueMemberS{Value: "A"}
+
+	if err := table.Put(ctx, shoutItem{ID: "a", ItemCount: 1}, If(AttributeNotExists("id").Or(Equal(Name("id"), shoutID("a"))))); err != nil {
+		t.Fatal(err)
+	}
+	put := client.last.(*dynamodb.PutItemInput)
+	if diff := cmp.Diff(want, put.ExpressionAttributeValues[":v0"], cmpopts.IgnoreUnexported(types.AttributeValueMemberS{})); diff != "" {
+		t.Errorf("condition value missmatch (-want, +got):\n%s", diff)
+	}
+
+	var got shoutItem
+	if err := table.Get(ctx, shoutItem{ID: "a"}, &got, Projecting(Project(got))); err != nil {
+		t.Fatal(err)
+	}
+	get := client.last.(*dynamodb.GetItemInput)
+	if expr := aws.ToString(get.ProjectionExpression); expr != "id, item_count" {
+		t.Errorf("projection: want %q, got %q", "id, item_count", expr)
+	}
+	if got.ItemCount != 1 {
+		t.Errorf("bad item: %+v", got)
+	}
+
+	var all []shoutItem
+	if err := table.Query("id", shoutID("a")).Filter(GreaterThan(Name("item_count"), 0)).All(ctx, &all); err != nil {
+		t.Fatal(err)
+	}
+	query := client.last.(*dynamodb.QueryInput)
+	if diff := cmp.Diff(want, query.ExpressionAttributeValues[":v0"], cmpopts.IgnoreUnexported(types.AttributeValueMemberS{})); diff != "" {
+		t.Errorf("key value missmatch (-want, +got):\n%s", diff)
+	}
+}
+
+func TestTableKey(t *testing.T) {
+	table := NewDB(newFakeClient()).Table("items")
+
+	type ranged struct {
+		PK    string `dynamodb:"pk,hash"`
+		SK    int    `dynamodb:"sk,range"`
+		Email string `dynamodb:"email,index=ByEmail"`
+	}
+	key, err := table.key(&ranged{PK: "a", SK: 1, Email: "e"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]types.AttributeValue{
+		"pk": &types.AttributeValueMemberS{Value: "a"},
+		"sk": &types.AttributeValueMemberN{Value: "1"},
+	}
+	if diff := cmp.Diff(want, key, cmpopts.IgnoreUnexported(types.AttributeValueMemberS{}, types.AttributeValueMemberN{})); diff != "" {
+		t.Errorf("missmatch (-want, +got):\n%s", diff)
+	}
+
+	type malformed struct {
+		ID   string `dynamodb:"id,hash"`
+		Name string `dynamodb:"name,lsi=ByName,hash"`
+	}
+	if _, err := table.key(malformed{ID: "a"}); err == nil || !strings.Contains(err.Error(), "local index") {
+		t.Errorf("malformed key options: want the tag error, got %v", err)
+	}
+
+	type untagged struct {
+		ID string `dynamodb:"id"`
+	}
+	if key, err := table.key(untagged{ID: "a"}); err != nil || len(key) != 1 {
+		t.Errorf("no key options: want the whole struct, got %v (err: %v)", key, err)
+	}
+}
@@ -2,6 +2,7 @@ package fuel
 
 import (
 	"encoding"
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"strconv"
@@ -22,17 +23,92 @@ type ItemUnmarshaler interface {
 	UnmarshalDynamoDBItem(item map[string]types.AttributeValue) error
 }
 
+// Decoder converts DynamoDB attribute values into Go values.
+// The zero value is not usable; create one with NewDecoder.
+type Decoder struct {
+	fieldOpts fieldOptions
+
+	lenient  bool
+	onCoerce func(Coercion)
+
+	hooks     []DecodeHook
+	aliasRule AliasRule
+	matching  NameMatching
+
+	// interface{} decoding
+	numberMode NumberMode
+	setMode    SetMode
+	binaryMode BinaryMode
+}
+
+// DecoderOption configures a Decoder.
+type DecoderOption interface {
+	applyDecoder(d *Decoder)
+}
+
+type decoderOptionFunc func(*Decoder)
+
+func (fn decoderOptionFunc) applyDecoder(d *Decoder) {
+	fn(d)
+}
+
+// NewDecoder returns a Decoder configured with the given options.
+func NewDecoder(opts ...DecoderOption) *Decoder {
+	d := &Decoder{}
+	for _, opt := range opts {
+		opt.applyDecoder(d)
+	}
+	return d
+}
+
+var defaultDecoder = NewDecoder()
+
+// UnmarshalAppend decodes item into a new element appended to the slice pointed to by out
 func UnmarshalAppend(item map[string]types.AttributeValue, out interface{}) error {
-	return unmarshalAppend(item, out)
+	return defaultDecoder.UnmarshalAppend(item, out)
 }
 
+// UnmarshalItems decodes items, such as the results of a Query or Scan page,
+// appending them to the slice pointed to by out. Elements may be values or pointers.
+// If an item fails to decode, an *ItemError is returned and out is left unchanged.
+func UnmarshalItems(items []map[string]types.AttributeValue, out interface{}) error {
+	return defaultDecoder.UnmarshalItems(items, out)
+}
+
+// UnmarshalItem decodes item into the struct or map pointed to by out
 func UnmarshalItem(item map[string]types.AttributeValue, out interface{}) error {
-	return unmarshalItem(item, out)
+	return defaultDecoder.UnmarshalItem(item, out)
 }
 
+// Unmarshal decodes a single AttributeValue into the value pointed to by out,
+// which must be a non-nil pointer
 func Unmarshal(av types.AttributeValue, out interface{}) error {
+	return defaultDecoder.Unmarshal(av, out)
+}
+
+// UnmarshalAppend decodes item into a new element appended to the slice pointed to by out
+func (d *Decoder) UnmarshalAppend(item map[string]types.AttributeValue, out interface{}) error {
+	return d.unmarshalAppend(item, out)
+}
+
+// UnmarshalItems decodes items, appending them to the slice pointed to by out
+func (d *Decoder) UnmarshalItems(items []map[string]types.AttributeValue, out interface{}) error {
+	return d.unmarshalItems(items, out)
+}
+
+// UnmarshalItem decodes item into the struct or map pointed to by out
+func (d *Decoder) UnmarshalItem(item map[string]types.AttributeValue, out interface{}) error {
+	return d.decodeItem(item, out)
+}
+
+// Unmarshal decodes a single AttributeValue into the value pointed to by out,
+// which must be a non-nil pointer. Hooks see the pointed-to type.
+func (d *Decoder) Unmarshal(av types.AttributeValue, out interface{}) error {
 	rv := reflect.ValueOf(out)
-	return unmarshalReflect(av, rv)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("dynamodb: unmarshal: not a pointer: %T", out)
+	}
+	return d.unmarshalReflect(av, rv.Elem(), nil)
 }
 
 var (
@@ -40,8 +116,18 @@ var (
 	tumType = reflect.TypeOf(&nilTum).Elem()
 )
 
-// unmarshal one value
-func unmarshalReflect(av types.AttributeValue, rv reflect.Value) error {
+// unmarshal one value, found at path
+func (d *Decoder) unmarshalReflect(av types.AttributeValue, rv reflect.Value, path *decodePath) error {
+	if len(d.hooks) > 0 && rv.CanSet() {
+		if ok, err := d.decodeHook(av, rv); ok {
+			return err
+		}
+	}
+
+	if d.lenient {
+		av = d.coerce(av, rv.Type(), path)
+	}
+
 	// first try interface unmarshal stuff
 	if rv.CanInterface() {
 		var iface interface{}
@@ -126,6 +212,11 @@ func unmarshalReflect(av types.AttributeValue, rv reflect.Value) error {
 				*x = *y
 				return nil
 			}
+		case *json.Number:
+			if avN, ok := av.(*types.AttributeValueMemberN); ok {
+				*x = json.Number(avN.Value)
+				return nil
+			}
 		case Unmarshaler:
 			return x.UnmarshalDynamoDB(av)
 		case encoding.TextUnmarshaler:
@@ -149,7 +240,7 @@ func unmarshalReflect(av types.AttributeValue, rv reflect.Value) error {
 		pt := reflect.New(rv.Type().Elem())
 		rv.Set(pt)
 		if avNULL, ok := av.(*types.AttributeValueMemberNULL); !ok || !(avNULL.Value) {
-			return unmarshalReflect(av, rv.Elem())
+			return d.unmarshalReflect(av, rv.Elem(), path)
 		}
 		return nil
 	case reflect.Bool:
@@ -204,7 +295,7 @@ func unmarshalReflect(av types.AttributeValue, rv reflect.Value) error {
 		if !ok {
 			return fmt.Errorf("dynamodb: cannot unmarshal %s data into struct", avTypeName(av))
 		}
-		if err := unmarshalItem(avM.Value, rv.Addr().Interface()); err != nil {
+		if err := d.unmarshalItem(avM.Value, rv.Addr().Interface(), path); err != nil {
 			return err
 		}
 		return nil
@@ -237,7 +328,7 @@ func unmarshalReflect(av types.AttributeValue, rv reflect.Value) error {
 			kv := kp.Elem()
 			for k, v := range x.Value {
 				innerRV := reflect.New(rv.Type().Elem())
-				if err := unmarshalReflect(v, innerRV.Elem()); err != nil {
+				if err := d.unmarshalReflect(v, innerRV.Elem(), d.field(path, k)); err != nil {
 					return err
 				}
 				if kp.Type().Implements(tumType) {
@@ -269,7 +360,7 @@ func unmarshalReflect(av types.AttributeValue, rv reflect.Value) error {
 		case *types.AttributeValueMemberNS:
 			kv := reflect.New(rv.Type().Key()).Elem()
 			for _, n := range x.Value {
-				if err := unmarshalReflect(&types.AttributeValueMemberN{Value: n}, kv); err != nil {
+				if err := d.unmarshalReflect(&types.AttributeValueMemberN{Value: n}, kv, path); err != nil {
 					return err
 				}
 				rv.SetMapIndex(kv, truthy)
@@ -286,7 +377,7 @@ func unmarshalReflect(av types.AttributeValue, rv reflect.Value) error {
 			return fmt.Errorf("dynamodb: cannot unmarshal %s vdata into map", avTypeName(av))
 		}
 	case reflect.Slice:
-		return unmarshalSlice(av, rv)
+		return d.unmarshalSlice(av, rv, path)
 	case reflect.Array:
 		arr := reflect.New(rv.Type()).Elem()
 		elemType := arr.Type().Elem()
@@ -304,7 +395,7 @@ func unmarshalReflect(av types.AttributeValue, rv reflect.Value) error {
 			}
 			for i, innerAV := range x.Value {
 				innerRV := reflect.New(elemType).Elem()
-				if err := unmarshalReflect(innerAV, innerRV); err != nil {
+				if err := d.unmarshalReflect(innerAV, innerRV, d.index(path, i)); err != nil {
 					return nil
 				}
 				arr.Index(i).Set(innerRV)
@@ -314,7 +405,7 @@ func unmarshalReflect(av types.AttributeValue, rv reflect.Value) error {
 		}
 	case reflect.Interface:
 		if rv.NumMethod() == 0 {
-			iface, err := av2iface(av)
+			iface, err := d.av2iface(av)
 			if err != nil {
 				return err
 			}
@@ -331,16 +422,16 @@ func unmarshalReflect(av types.AttributeValue, rv reflect.Value) error {
 	return fmt.Errorf("dynamodb: cannot unmarshal to type: %T (%+v)", iface, iface)
 }
 
-func unmarshalSlice(av types.AttributeValue, rv reflect.Value) error {
+func (d *Decoder) unmarshalSlice(av types.AttributeValue, rv reflect.Value, path *decodePath) error {
 	switch x := av.(type) {
 	case *types.AttributeValueMemberB:
 		rv.SetBytes(x.Value)
 		return nil
 	case *types.AttributeValueMemberL:
 		slicev := reflect.MakeSlice(rv.Type(), 0, len(x.Value))
-		for _, innerAV := range x.Value {
+		for i, innerAV := range x.Value {
 			innerRV := reflect.New(rv.Type().Elem()).Elem()
-			if err := unmarshalReflect(innerAV, innerRV); err != nil {
+			if err := d.unmarshalReflect(innerAV, innerRV, d.index(path, i)); err != nil {
 				return err
 			}
 			slicev = reflect.Append(slicev, innerRV)
@@ -353,7 +444,7 @@ func unmarshalSlice(av types.AttributeValue, rv reflect.Value) error {
 		slicev := reflect.MakeSlice(rv.Type(), 0, len(x.Value))
 		for _, b := range x.Value {
 			innerRV := reflect.New(rv.Type().Elem()).Elem()
-			if err := unmarshalReflect(&types.AttributeValueMemberB{Value: b}, innerRV); err != nil {
+			if err := d.unmarshalReflect(&types.AttributeValueMemberB{Value: b}, innerRV, path); err != nil {
 				return err
 			}
 			slicev = reflect.Append(slicev, innerRV)
@@ -364,7 +455,7 @@ func unmarshalSlice(av types.AttributeValue, rv reflect.Value) error {
 		slicev := reflect.MakeSlice(rv.Type(), 0, len(x.Value))
 		for _, str := range x.Value {
 			innerRV := reflect.New(rv.Type().Elem()).Elem()
-			if err := unmarshalReflect(&types.AttributeValueMemberS{Value: str}, innerRV); err != nil {
+			if err := d.unmarshalReflect(&types.AttributeValueMemberS{Value: str}, innerRV, path); err != nil {
 				return err
 			}
 			slicev = reflect.Append(slicev, innerRV)
@@ -375,7 +466,7 @@ func unmarshalSlice(av types.AttributeValue, rv reflect.Value) error {
 		slicev := reflect.MakeSlice(rv.Type(), 0, len(x.Value))
 		for _, n := range x.Value {
 			innerRV := reflect.New(rv.Type().Elem()).Elem()
-			if err := unmarshalReflect(&types.AttributeValueMemberN{Value: n}, innerRV); err != nil {
+			if err := d.unmarshalReflect(&types.AttributeValueMemberN{Value: n}, innerRV, path); err != nil {
 				return nil
 			}
 			slicev = reflect.Append(slicev, innerRV)
@@ -386,52 +477,16 @@ func unmarshalSlice(av types.AttributeValue, rv reflect.Value) error {
 	return fmt.Errorf("dynamodb: cannot unmarshal %s data into slice", avTypeName(av))
 }
 
-func fieldsInStruct(rv reflect.Value) map[string]reflect.Value {
-	if rv.Kind() == reflect.Ptr {
-		return fieldsInStruct(rv.Elem())
-	}
-
-	fields := make(map[string]reflect.Value)
-	for i := 0; i < rv.Type().NumField(); i++ {
-		field := rv.Type().Field(i)
-		fv := rv.Field(i)
-		isPtr := fv.Type().Kind() == reflect.Ptr
-
-		name, _ := fieldInfo(field)
-		if name == "-" {
-			// skip
-			continue
-		}
-
-		// embed anonymous structs, they could be pointers so test that too
-		if (fv.Type().Kind() == reflect.Struct || isPtr && fv.Type().Elem().Kind() == reflect.Struct) && field.Anonymous {
-			if isPtr {
-				// need to protect from setting unexported pointers because it will panic
-				if !fv.CanSet() {
-					continue
-				}
-				// set zero value for pointer
-				zero := reflect.New(fv.Type().Elem())
-				fv.Set(zero)
-				fv = zero
-			}
-
-			innerFields := fieldsInStruct(fv)
-			for k, v := range innerFields {
-				// don't clobber top-level fields
-				if _, ok := fields[k]; ok {
-					continue
-				}
-				fields[k] = v
-			}
-			continue
-		}
-		fields[name] = fv
+// decodeItem decodes a top-level item, migrating it to the current schema version first
+func (d *Decoder) decodeItem(item map[string]types.AttributeValue, out interface{}) error {
+	item, err := migrate(item, out)
+	if err != nil {
+		return err
 	}
-	return fields
+	return d.unmarshalItem(item, out, nil)
 }
 
-func unmarshalItem(item map[string]types.AttributeValue, out interface{}) error {
+func (d *Decoder) unmarshalItem(item map[string]types.AttributeValue, out interface{}, path *decodePath) error {
 	switch x := out.(type) {
 	case *map[string]types.AttributeValue:
 		*x = item
@@ -450,19 +505,9 @@ func unmarshalItem(item map[string]types.AttributeValue, out interface{}) error
 	switch rv.Elem().Kind() {
 	case reflect.Ptr:
 		rv.Elem().Set(reflect.New(rv.Elem().Type().Elem()))
-		return unmarshalItem(item, rv.Elem().Interface())
+		return d.unmarshalItem(item, rv.Elem().Interface(), path)
 	case reflect.Struct:
-		var err error
-		rv.Elem().Set(reflect.Zero(rv.Type().Elem()))
-		fields := fieldsInStruct(rv.Elem())
-		for name, fv := range fields {
-			if av, ok := item[name]; ok {
-				if innerErr := unmarshalReflect(av, fv); innerErr != nil {
-					err = innerErr
-				}
-			}
-		}
-		return err
+		return d.unmarshalStruct(item, rv, path)
 	case reflect.Map:
 		mapv := rv.Elem()
 		if mapv.Type().Key().Kind() != reflect.String {
@@ -474,7 +519,7 @@ func unmarshalItem(item map[string]types.AttributeValue, out interface{}) error
 
 		for k, av := range item {
 			innerRV := reflect.New(mapv.Type().Elem()).Elem()
-			if err := unmarshalReflect(av, innerRV); err != nil {
+			if err := d.unmarshalReflect(av, innerRV, d.field(path, k)); err != nil {
 				return err
 			}
 			mapv.SetMapIndex(reflect.ValueOf(k), innerRV)
@@ -484,7 +529,59 @@ func unmarshalItem(item map[string]types.AttributeValue, out interface{}) error
 	return fmt.Errorf("dynamodb: unmarshal: unsupported type: %T", out)
 }
 
-func unmarshalAppend(item map[string]types.AttributeValue, out interface{}) error {
+// unmarshalStruct decodes item, found at path, into the struct pointed to by rv
+func (d *Decoder) unmarshalStruct(item map[string]types.AttributeValue, rv reflect.Value, path *decodePath) error {
+	rv.Elem().Set(reflect.Zero(rv.Type().Elem()))
+	fields, err := cachedFields(rv.Elem().Type(), d.fieldOpts)
+	if err != nil {
+		return err
+	}
+	// embedded pointers are allocated only if one of their attributes is present,
+	// so apply defaults after everything else
+	var defaults []field
+	var folded map[string]string
+	if d.matching != MatchExact {
+		folded = d.foldKeys(item, fields.list)
+	}
+	for _, f := range fields.list {
+		name, ok, aliasErr := d.attribute(item, folded, f)
+		if aliasErr != nil {
+			err = aliasErr
+			continue
+		}
+		if !ok {
+			if f.hasDefault {
+				defaults = append(defaults, f)
+			}
+			continue
+		}
+		fv, settable := allocFieldByIndex(rv.Elem(), f.index)
+		if !settable {
+			continue
+		}
+		if innerErr := d.unmarshalReflect(item[name], fv, d.field(path, name)); innerErr != nil {
+			err = innerErr
+		}
+	}
+	for _, f := range defaults {
+		fv, ok := fieldByIndex(rv.Elem(), f.index)
+		if !ok {
+			continue
+		}
+		if innerErr := setDefault(fv, f.defaultValue); innerErr != nil {
+			err = fmt.Errorf("dynamodb: default for %s: %w", f.name, innerErr)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if defaulter, ok := rv.Interface().(Defaulter); ok {
+		defaulter.SetDefaults()
+	}
+	return nil
+}
+
+func (d *Decoder) unmarshalAppend(item map[string]types.AttributeValue, out interface{}) error {
 	if _, ok := out.(awsEncoder); ok {
 		return fmt.Errorf("dynamodb: unimplemented: aws encoder")
 	}
@@ -496,7 +593,7 @@ func unmarshalAppend(item map[string]types.AttributeValue, out interface{}) erro
 
 	slicev := rv.Elem()
 	innerRV := reflect.New(slicev.Type().Elem())
-	if err := unmarshalItem(item, innerRV.Interface()); err != nil {
+	if err := d.decodeItem(item, innerRV.Interface()); err != nil {
 		return err
 	}
 	slicev = reflect.Append(slicev, innerRV.Elem())
@@ -505,23 +602,65 @@ func unmarshalAppend(item map[string]types.AttributeValue, out interface{}) erro
 	return nil
 }
 
+// ItemError is returned when decoding one item of many fails
+type ItemError struct {
+	// Index of the failed item
+	Index int
+	Err   error
+}
+
+func (e *ItemError) Error() string {
+	return fmt.Sprintf("dynamodb: unmarshal items: item %d: %v", e.Index, e.Err)
+}
+
+func (e *ItemError) Unwrap() error {
+	return e.Err
+}
+
+func (d *Decoder) unmarshalItems(items []map[string]types.AttributeValue, out interface{}) error {
+	if _, ok := out.(awsEncoder); ok {
+		return fmt.Errorf("dynamodb: unimplemented: aws encoder")
+	}
+
+	rv := reflect.ValueOf(out)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("dynamodb: unmarshal items: result argument must be a slice pointer")
+	}
+
+	// grow into a new array even if out has room: its spare capacity may be shared,
+	// and must be left alone if an item fails to decode
+	slicev := rv.Elem()
+	n := slicev.Len()
+	grown := reflect.MakeSlice(slicev.Type(), n+len(items), n+len(items))
+	reflect.Copy(grown, slicev)
+
+	for i, item := range items {
+		if err := d.decodeItem(item, grown.Index(n+i).Addr().Interface()); err != nil {
+			return &ItemError{Index: i, Err: err}
+		}
+	}
+
+	rv.Elem().Set(grown)
+	return nil
+}
+
 // av2iface converts an AttributeValue into interface{}
-func av2iface(av types.AttributeValue) (interface{}, error) {
+func (d *Decoder) av2iface(av types.AttributeValue) (interface{}, error) {
 	switch x := av.(type) {
 	case *types.AttributeValueMemberB:
-		return x.Value, nil
+		return d.binary(x.Value), nil
 	case *types.AttributeValueMemberBS:
-		return x.Value, nil
+		return d.binarySet(x.Value), nil
 	case *types.AttributeValueMemberBOOL:
 		return x.Value, nil
 	case *types.AttributeValueMemberN:
-		return strconv.ParseFloat(x.Value, 64)
+		return d.number(x.Value)
 	case *types.AttributeValueMemberS:
 		return x.Value, nil
 	case *types.AttributeValueMemberL:
 		list := make([]interface{}, 0, len(x.Value))
 		for _, item := range x.Value {
-			iface, err := av2iface(item)
+			iface, err := d.av2iface(item)
 			if err != nil {
 				return nil, err
 			}
@@ -529,23 +668,25 @@ func av2iface(av types.AttributeValue) (interface{}, error) {
 		}
 		return list, nil
 	case *types.AttributeValueMemberNS:
-		set := make([]float64, 0, len(x.Value))
-		for _, n := range x.Value {
-			f, err := strconv.ParseFloat(n, 64)
-			if err != nil {
-				return nil, err
+		return d.numberSet(x.Value)
+	case *types.AttributeValueMemberSS:
+		switch d.setMode {
+		case SetAsSetType:
+			return append(StringSet(nil), x.Value...), nil
+		case SetAsMap:
+			set := make(map[string]struct{}, len(x.Value))
+			for _, s := range x.Value {
+				set[s] = struct{}{}
 			}
-			set = append(set, f)
+			return set, nil
 		}
-		return set, nil
-	case *types.AttributeValueMemberSS:
 		set := make([]string, 0, len(x.Value))
 		set = append(set, x.Value...)
 		return set, nil
 	case *types.AttributeValueMemberM:
 		m := make(map[string]interface{}, len(x.Value))
 		for k, v := range x.Value {
-			iface, err := av2iface(v)
+			iface, err := d.av2iface(v)
 			if err != nil {
 				return nil, err
 			}
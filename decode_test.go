@@ -1,6 +1,7 @@
 package fuel
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 
@@ -34,7 +35,8 @@ var itemDecodeOnlyTests = []struct {
 		}{},
 	},
 	{
-		// embedded pointers shouldn't clobber existing fields
+		// embedded pointers shouldn't clobber existing fields,
+		// and aren't allocated when none of their attributes are present
 		name: "exported pointer embedded struct clobber",
 		given: map[string]types.AttributeValue{
 			"Embedded": &types.AttributeValueMemberS{Value: "OK"},
@@ -43,10 +45,18 @@ var itemDecodeOnlyTests = []struct {
 			Embedded string
 			*ExportedEmbedded
 		}{
-			Embedded:         "OK",
-			ExportedEmbedded: &ExportedEmbedded{},
+			Embedded: "OK",
 		},
 	},
+	{
+		// defaults alone don't allocate embedded pointers
+		name:  "exported pointer embedded struct with default",
+		given: map[string]types.AttributeValue{},
+		want: struct {
+			*defaultEmbedded
+			*ExportedDefaultEmbedded
+		}{},
+	},
 }
 
 func TestUnmarshalAsymmetric(t *testing.T) {
@@ -84,7 +94,7 @@ func TestUnmarshalAppend(t *testing.T) {
 	}
 
 	for range [15]struct{}{} {
-		if err := unmarshalAppend(item, &results); err != nil {
+		if err := defaultDecoder.unmarshalAppend(item, &results); err != nil {
 			t.Fatal(err)
 		}
 	}
@@ -98,7 +108,7 @@ func TestUnmarshalAppend(t *testing.T) {
 	var mapResults []map[string]interface{}
 
 	for range [15]struct{}{} {
-		err := unmarshalAppend(item, &mapResults)
+		err := defaultDecoder.unmarshalAppend(item, &mapResults)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -114,7 +124,7 @@ func TestUnmarshalAppend(t *testing.T) {
 func TestUnmarshal(t *testing.T) {
 	for _, tc := range encodingTests {
 		rv := reflect.New(reflect.TypeOf(tc.in))
-		if err := unmarshalReflect(tc.out, rv.Elem()); err != nil {
+		if err := defaultDecoder.unmarshalReflect(tc.out, rv.Elem(), nil); err != nil {
 			t.Errorf("%s: unexpected error: %v", tc.name, err)
 			continue
 		}
@@ -125,10 +135,32 @@ func TestUnmarshal(t *testing.T) {
 	}
 }
 
+func TestUnmarshalPointer(t *testing.T) {
+	for _, tc := range encodingTests {
+		rv := reflect.New(reflect.TypeOf(tc.in))
+		if err := Unmarshal(tc.out, rv.Interface()); err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+			continue
+		}
+		if want, got := tc.in, rv.Elem().Interface(); !cmp.Equal(want, got) {
+			t.Errorf("%s: missmatch (-want, +got):\n%s", tc.name, cmp.Diff(want, got))
+		}
+	}
+
+	av := &types.AttributeValueMemberS{Value: "hello"}
+	var s string
+	if err := Unmarshal(av, s); err == nil {
+		t.Error("non-pointer: expected error")
+	}
+	if err := Unmarshal(av, (*string)(nil)); err == nil {
+		t.Error("nil pointer: expected error")
+	}
+}
+
 func TestUnmarshalItem(t *testing.T) {
 	for _, tc := range itemEncodingTests {
 		rv := reflect.New(reflect.TypeOf(tc.in))
-		if err := unmarshalItem(tc.out, rv.Interface()); err != nil {
+		if err := defaultDecoder.unmarshalItem(tc.out, rv.Interface(), nil); err != nil {
 			t.Errorf("%s: unexpected error: %v", tc.name, err)
 			continue
 		}
@@ -189,3 +221,77 @@ func TestUnmarshalNULL(t *testing.T) {
 		t.Errorf("unmarshal null: missmatch (-want, +got):\n%s", diff)
 	}
 }
+
+func TestUnmarshalItems(t *testing.T) {
+	type hit struct {
+		User int `dynamodb:"UserID"`
+		Page int
+	}
+	items := []map[string]types.AttributeValue{
+		{
+			"UserID": &types.AttributeValueMemberN{Value: "1"},
+			"Page":   &types.AttributeValueMemberN{Value: "5"},
+		},
+		{
+			"UserID": &types.AttributeValueMemberN{Value: "2"},
+		},
+	}
+
+	results := []hit{{User: 100}}
+	if err := UnmarshalItems(items, &results); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]hit{{User: 100}, {User: 1, Page: 5}, {User: 2}}, results); diff != "" {
+		t.Errorf("values: missmatch (-want, +got):\n%s", diff)
+	}
+
+	var ptrs []*hit
+	if err := UnmarshalItems(items, &ptrs); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]*hit{{User: 1, Page: 5}, {User: 2}}, ptrs); diff != "" {
+		t.Errorf("pointers: missmatch (-want, +got):\n%s", diff)
+	}
+
+	var maps []map[string]interface{}
+	if err := UnmarshalItems(items, &maps); err != nil {
+		t.Fatal(err)
+	}
+	if len(maps) != 2 || maps[1]["UserID"] != 2.0 {
+		t.Errorf("maps: bad result %v", maps)
+	}
+}
+
+func TestUnmarshalItemsError(t *testing.T) {
+	items := []map[string]types.AttributeValue{
+		{"A": &types.AttributeValueMemberN{Value: "1"}},
+		{"A": &types.AttributeValueMemberS{Value: "oops"}},
+	}
+
+	var results []struct{ A int }
+	err := UnmarshalItems(items, &results)
+	var itemErr *ItemError
+	if !errors.As(err, &itemErr) {
+		t.Fatalf("want *ItemError, got %v", err)
+	}
+	if itemErr.Index != 1 {
+		t.Errorf("bad index: want 1, got %d", itemErr.Index)
+	}
+	if results != nil {
+		t.Errorf("out was modified: %v", results)
+	}
+
+	// spare capacity belongs to the caller too
+	backing := make([]struct{ A int }, 1, 3)
+	results = backing[:0]
+	if err := UnmarshalItems(items, &results); err == nil {
+		t.Fatal("expected error")
+	}
+	if len(results) != 0 || backing[:3][0].A != 0 || backing[:3][1].A != 0 {
+		t.Errorf("backing array was modified: %v", backing[:3])
+	}
+
+	if err := UnmarshalItems(items, results); err == nil {
+		t.Error("non-pointer: expected error")
+	}
+}
@@ -0,0 +1,100 @@
+package fuel
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Defaulter is the interface implemented by types that compute default values
+// for themselves. SetDefaults is called after a struct is decoded from an item.
+type Defaulter interface {
+	SetDefaults()
+}
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	timeType     = reflect.TypeOf(time.Time{})
+)
+
+// tagOption returns the value of a key=value option in the dynamodb struct tag of field
+func tagOption(field reflect.StructField, key string) (string, bool) {
+	tags := strings.Split(field.Tag.Get("dynamodb"), ",")
+	for _, t := range tags[1:] {
+		if strings.HasPrefix(t, key+"=") {
+			return t[len(key)+1:], true
+		}
+	}
+	return "", false
+}
+
+// setDefault parses value according to the type of rv and sets it
+func setDefault(rv reflect.Value, value string) error {
+	if rv.Kind() == reflect.Ptr {
+		ptr := reflect.New(rv.Type().Elem())
+		if err := setDefault(ptr.Elem(), value); err != nil {
+			return err
+		}
+		rv.Set(ptr)
+		return nil
+	}
+
+	switch rv.Type() {
+	case durationType:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		rv.SetInt(int64(d))
+		return nil
+	case timeType:
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return err
+		}
+		rv.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	if tum, ok := rv.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return tum.UnmarshalText([]byte(value))
+	}
+
+	switch rv.Kind() {
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		rv.SetBool(b)
+		return nil
+	case reflect.Int, reflect.Int64, reflect.Int32, reflect.Int16, reflect.Int8:
+		n, err := strconv.ParseInt(value, 10, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetInt(n)
+		return nil
+	case reflect.Uint, reflect.Uint64, reflect.Uint32, reflect.Uint16, reflect.Uint8:
+		n, err := strconv.ParseUint(value, 10, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetUint(n)
+		return nil
+	case reflect.Float64, reflect.Float32:
+		n, err := strconv.ParseFloat(value, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetFloat(n)
+		return nil
+	case reflect.String:
+		rv.SetString(value)
+		return nil
+	}
+	return fmt.Errorf("unsupported type for default value: %s", rv.Type())
+}
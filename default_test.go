@@ -0,0 +1,83 @@
+package fuel
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/go-cmp/cmp"
+)
+
+type defaultItem struct {
+	MaxRetries int           `dynamodb:",default=3"`
+	Status     string        `dynamodb:"status,default=active"`
+	Ratio      float64       `dynamodb:",default=0.5"`
+	Enabled    bool          `dynamodb:",default=true"`
+	Timeout    time.Duration `dynamodb:",default=1m30s"`
+	Since      time.Time     `dynamodb:",default=2019-01-01T00:00:00Z"`
+	Limit      *uint         `dynamodb:",default=10"`
+	Flag       textMarshaler `dynamodb:",default=true"`
+	NoDefault  int
+
+	computed string
+}
+
+func (item *defaultItem) SetDefaults() {
+	item.computed = item.Status + "!"
+}
+
+func TestUnmarshalDefaults(t *testing.T) {
+	var got defaultItem
+	if err := UnmarshalItem(map[string]types.AttributeValue{}, &got); err != nil {
+		t.Fatal(err)
+	}
+	want := defaultItem{
+		MaxRetries: 3,
+		Status:     "active",
+		Ratio:      0.5,
+		Enabled:    true,
+		Timeout:    90 * time.Second,
+		Since:      time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+		Limit:      aws.Uint(10),
+		Flag:       true,
+		computed:   "active!",
+	}
+	if diff := cmp.Diff(want, got, cmp.AllowUnexported(defaultItem{})); diff != "" {
+		t.Errorf("missmatch (-want, +got):\n%s", diff)
+	}
+}
+
+func TestUnmarshalDefaultsPresent(t *testing.T) {
+	item := map[string]types.AttributeValue{
+		"MaxRetries": &types.AttributeValueMemberN{Value: "0"},
+		"status":     &types.AttributeValueMemberS{Value: "archived"},
+		"Enabled":    &types.AttributeValueMemberNULL{Value: true},
+	}
+	var got defaultItem
+	if err := UnmarshalItem(item, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.MaxRetries != 0 || got.Status != "archived" || got.Enabled || got.computed != "archived!" {
+		t.Errorf("present attributes were overwritten: %+v", got)
+	}
+	if got.Ratio != 0.5 {
+		t.Errorf("missing attribute was not defaulted: %+v", got)
+	}
+}
+
+func TestUnmarshalDefaultsInvalid(t *testing.T) {
+	var got struct {
+		N int `dynamodb:",default=many"`
+	}
+	if err := UnmarshalItem(map[string]types.AttributeValue{}, &got); err == nil {
+		t.Error("expected error, got nil")
+	}
+
+	var unsupported struct {
+		L []string `dynamodb:",default=a"`
+	}
+	if err := UnmarshalItem(map[string]types.AttributeValue{}, &unsupported); err == nil {
+		t.Error("expected error, got nil")
+	}
+}
@@ -0,0 +1,281 @@
+package fuel
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// AttributeType is a DynamoDB attribute type, such as "S" or "NS"
+type AttributeType string
+
+// Attribute types. AnyType means the type depends on the value,
+// for example for interface{} fields or types with custom marshalers.
+const (
+	AnyType       AttributeType = ""
+	StringType    AttributeType = "S"
+	NumberType    AttributeType = "N"
+	BinaryType    AttributeType = "B"
+	BoolType      AttributeType = "BOOL"
+	NullType      AttributeType = "NULL"
+	ListType      AttributeType = "L"
+	MapType       AttributeType = "M"
+	StringSetType AttributeType = "SS"
+	NumberSetType AttributeType = "NS"
+	BinarySetType AttributeType = "BS"
+)
+
+// Schema describes how a struct type is laid out as a DynamoDB item
+type Schema struct {
+	Type       reflect.Type
+	Attributes []Attribute
+}
+
+// Attribute describes a struct field and the attribute it is encoded as.
+// The VersionAttribute of types with migrations has no Field or Index.
+type Attribute struct {
+	// Name of the attribute
+	Name string
+	// Field is the name of the Go field
+	Field string
+	// Embedded lists the embedded struct fields the field is promoted through, outermost first
+	Embedded []string
+	// Index of the field, as used by reflect.Value.FieldByIndex
+	Index []int
+	// GoType is the type of the field
+	GoType reflect.Type
+	// AttributeType is the DynamoDB type the field is encoded as.
+	// Fields with the null flag may also be encoded as NULL.
+	AttributeType AttributeType
+
+	Set            bool
+	OmitEmpty      bool
+	OmitEmptyElem  bool
+	AllowEmpty     bool
+	AllowEmptyElem bool
+	Null           bool
+	UnixTime       bool
+
+	// Default is the value of the default= tag option, if HasDefault
+	Default    string
+	HasDefault bool
+	// Aliases are alternative names accepted when decoding
+	Aliases []string
+
+	// Nested describes the struct encoded as this attribute's map, or as the
+	// members of its list or map. Recursive types are only expanded once.
+	Nested *Schema
+}
+
+// Describe returns the attribute layout of struct type t, as used by MarshalItem and UnmarshalItem.
+// For types with registered migrations, it includes the VersionAttribute.
+func Describe(t reflect.Type) (*Schema, error) {
+	return defaultEncoder.Describe(t)
+}
+
+// Describe returns the attribute layout of struct type t, as used by this Encoder.
+func (e *Encoder) Describe(t reflect.Type) (*Schema, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("dynamodb: describe: not a struct: %s", t)
+	}
+	schema, err := e.describe(t, make(map[reflect.Type]bool))
+	if err != nil {
+		return nil, err
+	}
+	// MarshalItem stamps the version of top-level items only
+	if lookupSchema(t) != nil {
+		schema.Attributes = append(schema.Attributes, Attribute{
+			Name:          VersionAttribute,
+			GoType:        reflect.TypeOf(0),
+			AttributeType: NumberType,
+		})
+	}
+	return schema, nil
+}
+
+func (e *Encoder) describe(t reflect.Type, seen map[reflect.Type]bool) (*Schema, error) {
+	fields, err := cachedFields(t, e.fieldOpts)
+	if err != nil {
+		return nil, err
+	}
+	seen[t] = true
+	defer delete(seen, t)
+
+	schema := &Schema{Type: t}
+	for _, f := range fields.list {
+		sf := t.FieldByIndex(f.index)
+		attr := Attribute{
+			Name:           f.name,
+			Field:          sf.Name,
+			Embedded:       embeddedPath(t, f.index),
+			Index:          append([]int(nil), f.index...),
+			GoType:         sf.Type,
+			AttributeType:  e.attributeType(sf.Type, f.flags),
+			Set:            f.flags&flagSet != 0,
+			OmitEmpty:      f.flags&flagOmitEmpty != 0,
+			OmitEmptyElem:  f.flags&flagOmitEmptyElem != 0,
+			AllowEmpty:     f.flags&flagAllowEmpty != 0,
+			AllowEmptyElem: f.flags&flagAllowEmptyElem != 0,
+			Null:           f.flags&flagNull != 0,
+			UnixTime:       f.flags&flagUnixTime != 0,
+			Default:        f.defaultValue,
+			HasDefault:     f.hasDefault,
+			Aliases:        append([]string(nil), f.aliases...),
+		}
+		if nt := e.nestedStruct(sf.Type, attr.AttributeType); nt != nil && !seen[nt] {
+			if attr.Nested, err = e.describe(nt, seen); err != nil {
+				return nil, err
+			}
+		}
+		schema.Attributes = append(schema.Attributes, attr)
+	}
+	return schema, nil
+}
+
+// embeddedPath returns the names of the embedded fields leading to the field at index
+func embeddedPath(t reflect.Type, index []int) []string {
+	var path []string
+	for _, i := range index[:len(index)-1] {
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		sf := t.Field(i)
+		path = append(path, sf.Name)
+		t = sf.Type
+	}
+	return path
+}
+
+// nestedStruct returns the struct type encoded as a map, list or map members for attributes of type t
+func (e *Encoder) nestedStruct(t reflect.Type, at AttributeType) reflect.Type {
+	t = derefType(t)
+	switch at {
+	case ListType:
+		t = derefType(t.Elem())
+	case MapType:
+		if t.Kind() == reflect.Map {
+			t = derefType(t.Elem())
+		}
+	default:
+		return nil
+	}
+	if t.Kind() != reflect.Struct || e.attributeType(t, flagNone) != MapType {
+		return nil
+	}
+	return t
+}
+
+func derefType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+var (
+	marshalerType = reflect.TypeOf((*Marshaler)(nil)).Elem()
+	avMemberTypes = map[reflect.Type]AttributeType{
+		reflect.TypeOf(types.AttributeValueMemberS{}):    StringType,
+		reflect.TypeOf(types.AttributeValueMemberN{}):    NumberType,
+		reflect.TypeOf(types.AttributeValueMemberB{}):    BinaryType,
+		reflect.TypeOf(types.AttributeValueMemberBOOL{}): BoolType,
+		reflect.TypeOf(types.AttributeValueMemberNULL{}): NullType,
+		reflect.TypeOf(types.AttributeValueMemberL{}):    ListType,
+		reflect.TypeOf(types.AttributeValueMemberM{}):    MapType,
+		reflect.TypeOf(types.AttributeValueMemberSS{}):   StringSetType,
+		reflect.TypeOf(types.AttributeValueMemberNS{}):   NumberSetType,
+		reflect.TypeOf(types.AttributeValueMemberBS{}):   BinarySetType,
+	}
+	knownTypes = map[reflect.Type]AttributeType{
+		reflect.TypeOf(json.Number("")): NumberType,
+		reflect.TypeOf(Number("")):      NumberType,
+		reflect.TypeOf(StringSet{}):     StringSetType,
+		reflect.TypeOf(NumberSet{}):     NumberSetType,
+		reflect.TypeOf(BinarySet{}):     BinarySetType,
+	}
+)
+
+// attributeType follows the rules of marshal to predict what type values of t are encoded as
+func (e *Encoder) attributeType(t reflect.Type, flags encodeFlags) AttributeType {
+	// same precedence as marshal: hooks first, then unixtime
+	if _, ok := e.hooks[t]; ok {
+		return AnyType
+	}
+	if flags&flagUnixTime != 0 {
+		switch t {
+		case timeType:
+			return NumberType
+		case reflect.PtrTo(timeType):
+			return e.attributeType(timeType, flags)
+		}
+	}
+	if t.Kind() == reflect.Ptr {
+		if at, ok := avMemberTypes[t.Elem()]; ok {
+			return at
+		}
+	}
+	if at, ok := knownTypes[t]; ok {
+		return at
+	}
+
+	switch {
+	case t.Implements(avType), t.Implements(marshalerType):
+		return AnyType
+	case t.Implements(tmType):
+		return StringType
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return e.attributeType(t.Elem(), flags)
+	case reflect.Bool:
+		return BoolType
+	case reflect.Int, reflect.Int64, reflect.Int32, reflect.Int16, reflect.Int8,
+		reflect.Uint, reflect.Uint64, reflect.Uint32, reflect.Uint16, reflect.Uint8,
+		reflect.Float32, reflect.Float64:
+		return NumberType
+	case reflect.String:
+		return StringType
+	case reflect.Struct:
+		return MapType
+	case reflect.Map:
+		if flags&flagSet != 0 {
+			return setType(t.Key())
+		}
+		return MapType
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return BinaryType
+		}
+		if flags&flagSet != 0 {
+			return setType(t.Elem())
+		}
+		return ListType
+	}
+	return AnyType
+}
+
+// setType returns the type of set with members of type t
+func setType(t reflect.Type) AttributeType {
+	if t.Implements(tmType) {
+		return StringSetType
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int64, reflect.Int32, reflect.Int16, reflect.Int8,
+		reflect.Uint, reflect.Uint64, reflect.Uint32, reflect.Uint16, reflect.Uint8,
+		reflect.Float32, reflect.Float64:
+		return NumberSetType
+	case reflect.String:
+		return StringSetType
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return BinarySetType
+		}
+	}
+	return AnyType
+}
@@ -0,0 +1,170 @@
+package fuel
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/go-cmp/cmp"
+)
+
+type describeLine struct {
+	SKU string `dynamodb:"sku"`
+}
+
+type describeItem struct {
+	ID      string    `dynamodb:"id,alias=Id"`
+	Count   int       `dynamodb:",omitempty,default=1"`
+	Tags    []string  `dynamodb:",set"`
+	TTL     time.Time `dynamodb:",unixtime"`
+	Lines   []describeLine
+	Any     interface{}
+	Custom  customMarshaler
+	Skipped string `dynamodb:"-"`
+	*ExportedEmbedded
+}
+
+func TestDescribe(t *testing.T) {
+	schema, err := Describe(reflect.TypeOf(&describeItem{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type summary struct {
+		Name     string
+		Field    string
+		Embedded []string
+		Type     AttributeType
+	}
+	var got []summary
+	for _, attr := range schema.Attributes {
+		got = append(got, summary{Name: attr.Name, Field: attr.Field, Embedded: attr.Embedded, Type: attr.AttributeType})
+	}
+	want := []summary{
+		{Name: "id", Field: "ID", Type: StringType},
+		{Name: "Count", Field: "Count", Type: NumberType},
+		{Name: "Tags", Field: "Tags", Type: StringSetType},
+		{Name: "TTL", Field: "TTL", Type: NumberType},
+		{Name: "Lines", Field: "Lines", Type: ListType},
+		{Name: "Any", Field: "Any", Type: AnyType},
+		{Name: "Custom", Field: "Custom", Type: AnyType},
+		{Name: "Embedded", Field: "Embedded", Embedded: []string{"ExportedEmbedded"}, Type: BoolType},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("missmatch (-want, +got):\n%s", diff)
+	}
+
+	id, count, tags, lines := schema.Attributes[0], schema.Attributes[1], schema.Attributes[2], schema.Attributes[4]
+	if diff := cmp.Diff([]string{"Id"}, id.Aliases); diff != "" {
+		t.Errorf("aliases missmatch (-want, +got):\n%s", diff)
+	}
+	if !count.OmitEmpty || !count.HasDefault || count.Default != "1" {
+		t.Errorf("bad flags: %+v", count)
+	}
+	if !tags.Set {
+		t.Errorf("bad flags: %+v", tags)
+	}
+	if lines.Nested == nil || len(lines.Nested.Attributes) != 1 || lines.Nested.Attributes[0].Name != "sku" {
+		t.Errorf("bad nested schema: %+v", lines.Nested)
+	}
+
+	if _, err := Describe(reflect.TypeOf(0)); err == nil {
+		t.Error("non-struct: expected error, got nil")
+	}
+}
+
+// TestDescribeMatchesMarshal checks that Describe agrees with MarshalItem
+func TestDescribeMatchesMarshal(t *testing.T) {
+	tests := append(itemEncodingTests[:len(itemEncodingTests):len(itemEncodingTests)], struct {
+		name string
+		in   interface{}
+		out  map[string]types.AttributeValue
+	}{
+		// stamped with the version attribute
+		name: "migrated",
+		in:   migratedUser{First: "Alice"},
+	})
+	for _, tc := range tests {
+		rt := reflect.TypeOf(tc.in)
+		if derefType(rt).Kind() != reflect.Struct {
+			continue
+		}
+		schema, err := Describe(rt)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+			continue
+		}
+		item, err := MarshalItem(tc.in)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+			continue
+		}
+
+		attrs := make(map[string]Attribute)
+		for _, attr := range schema.Attributes {
+			attrs[attr.Name] = attr
+		}
+		for name, av := range item {
+			attr, ok := attrs[name]
+			if !ok {
+				t.Errorf("%s: attribute %s not described", tc.name, name)
+				continue
+			}
+			if _, isNull := av.(*types.AttributeValueMemberNULL); isNull || attr.AttributeType == AnyType {
+				continue
+			}
+			if got := avAttributeType(av); got != attr.AttributeType {
+				t.Errorf("%s: attribute %s: described as %s, encoded as %s", tc.name, name, attr.AttributeType, got)
+			}
+		}
+	}
+}
+
+func avAttributeType(av types.AttributeValue) AttributeType {
+	for rt, at := range avMemberTypes {
+		if reflect.TypeOf(av).Elem() == rt {
+			return at
+		}
+	}
+	return AnyType
+}
+
+func TestDescribeHookedUnixTime(t *testing.T) {
+	type event struct {
+		At  time.Time  `dynamodb:",unixtime"`
+		End *time.Time `dynamodb:",unixtime"`
+	}
+	enc := NewEncoder(UseEncodeHook(reflect.TypeOf(time.Time{}), func(v interface{}) (types.AttributeValue, error) {
+		return &types.AttributeValueMemberS{Value: v.(time.Time).Format(time.RFC3339)}, nil
+	}))
+	schema, err := enc.Describe(reflect.TypeOf(event{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, attr := range schema.Attributes {
+		if attr.AttributeType != AnyType {
+			t.Errorf("%s: want %s, got %s", attr.Name, AnyType, attr.AttributeType)
+		}
+	}
+	end := time.Unix(2, 0)
+	item, err := enc.MarshalItem(event{At: time.Unix(1, 0), End: &end})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, av := range item {
+		if _, ok := av.(*types.AttributeValueMemberS); !ok {
+			t.Errorf("%s: hook not used: %#v", name, av)
+		}
+	}
+
+	schema, err = Describe(reflect.TypeOf(event{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, attr := range schema.Attributes {
+		if attr.AttributeType != NumberType {
+			t.Errorf("%s: want %s, got %s", attr.Name, NumberType, attr.AttributeType)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package fuel
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// DiffOption configures UpdateFromDiff.
+type DiffOption func(*diffOptions)
+
+type diffOptions struct {
+	nested bool
+}
+
+// DiffNested makes UpdateFromDiff compare maps (such as nested structs) attribute by attribute,
+// updating the document paths that changed instead of the whole map.
+// Lists are always replaced as a whole.
+func DiffNested() DiffOption {
+	return func(o *diffOptions) {
+		o.nested = true
+	}
+}
+
+// UpdateFromDiff marshals old and new with MarshalItem and returns the update that turns
+// the item of old into the item of new: attributes that changed are SET and attributes
+// that are gone are REMOVEd. Use it with UpdateItem instead of putting new, so that
+// concurrent writes to other attributes aren't lost.
+//
+// The returned condition holds if the attributes the update changes still have their
+// old values (or are still missing), so that the update can fail instead of overwriting
+// a concurrent change. The version attribute of migrated types is never part of it.
+// If nothing changed, the update is empty and the condition is the empty condition.
+//
+// old and new must have the same type, and their keys (the table hash and range options,
+// see CreateTableInput) must not differ, since UpdateItem can't change them.
+func UpdateFromDiff(old, new interface{}, opts ...DiffOption) (*Update, Condition, error) {
+	return defaultEncoder.UpdateFromDiff(old, new, opts...)
+}
+
+// UpdateFromDiff returns the update that turns the item of old into the item of new.
+// See the package-level UpdateFromDiff.
+func (e *Encoder) UpdateFromDiff(old, new interface{}, opts ...DiffOption) (*Update, Condition, error) {
+	var o diffOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if ot, nt := indirectType(old), indirectType(new); ot != nt {
+		return nil, Condition{}, fmt.Errorf("dynamodb: diff: type mismatch: %v and %v", ot, nt)
+	}
+
+	oldItem, err := e.MarshalItem(old)
+	if err != nil {
+		return nil, Condition{}, err
+	}
+	newItem, err := e.MarshalItem(new)
+	if err != nil {
+		return nil, Condition{}, err
+	}
+	if err := e.sameKeys(indirectType(old), oldItem, newItem); err != nil {
+		return nil, Condition{}, err
+	}
+
+	d := itemDiff{update: NewUpdate(), nested: o.nested}
+	d.diff(nil, oldItem, newItem)
+	return d.update, And(d.conds...), nil
+}
+
+// sameKeys checks that the table keys of the items old and new of type t are equal
+func (e *Encoder) sameKeys(t reflect.Type, old, new map[string]types.AttributeValue) error {
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+	keys, err := cachedTableKeys(t, e.fieldOpts)
+	if err != nil {
+		return err
+	}
+	for _, name := range []string{keys.hash, keys.rangeKey} {
+		if name == "" {
+			continue
+		}
+		a, b := old[name], new[name]
+		if (a != nil || b != nil) && !avEqual(a, b) {
+			return fmt.Errorf("dynamodb: diff: key attribute %s differs, and UpdateItem can't change keys", name)
+		}
+	}
+	return nil
+}
+
+type itemDiff struct {
+	update *Update
+	conds  []Condition
+	nested bool
+}
+
+// diff adds the changes between the maps at prefix to d
+func (d *itemDiff) diff(prefix []pathElem, old, new map[string]types.AttributeValue) {
+	names := make([]string, 0, len(old)+len(new))
+	for name := range old {
+		names = append(names, name)
+	}
+	for name := range new {
+		if _, ok := old[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	// sorted, so that expressions are stable
+	sort.Strings(names)
+
+	for _, name := range names {
+		path := append(append(pathOperand(nil), prefix...), pathElem{name: name})
+		ov, inOld := old[name]
+		nv, inNew := new[name]
+		switch {
+		case !inNew:
+			d.update.remove = append(d.update.remove, path)
+		case !inOld:
+			d.update.setPath(path, Value(nv))
+		case avEqual(ov, nv):
+			continue
+		default:
+			om, oldMap := ov.(*types.AttributeValueMemberM)
+			nm, newMap := nv.(*types.AttributeValueMemberM)
+			if d.nested && oldMap && newMap {
+				d.diff(path, om.Value, nm.Value)
+				continue
+			}
+			d.update.setPath(path, Value(nv))
+		}
+
+		if len(path) == 1 && name == VersionAttribute {
+			continue
+		}
+		if inOld {
+			d.conds = append(d.conds, Equal(path, Value(ov)))
+		} else {
+			d.conds = append(d.conds, Condition{op: "attribute_not_exists", operands: []Operand{path}})
+		}
+	}
+}
+
+// avEqual reports whether a and b are the same value. Set members may be in any order.
+func avEqual(a, b types.AttributeValue) bool {
+	switch x := a.(type) {
+	case *types.AttributeValueMemberS:
+		y, ok := b.(*types.AttributeValueMemberS)
+		return ok && x.Value == y.Value
+	case *types.AttributeValueMemberN:
+		y, ok := b.(*types.AttributeValueMemberN)
+		return ok && x.Value == y.Value
+	case *types.AttributeValueMemberB:
+		y, ok := b.(*types.AttributeValueMemberB)
+		return ok && bytes.Equal(x.Value, y.Value)
+	case *types.AttributeValueMemberBOOL:
+		y, ok := b.(*types.AttributeValueMemberBOOL)
+		return ok && x.Value == y.Value
+	case *types.AttributeValueMemberNULL:
+		y, ok := b.(*types.AttributeValueMemberNULL)
+		return ok && x.Value == y.Value
+	case *types.AttributeValueMemberSS:
+		y, ok := b.(*types.AttributeValueMemberSS)
+		return ok && sameMembers(x.Value, y.Value)
+	case *types.AttributeValueMemberNS:
+		y, ok := b.(*types.AttributeValueMemberNS)
+		return ok && sameMembers(x.Value, y.Value)
+	case *types.AttributeValueMemberBS:
+		y, ok := b.(*types.AttributeValueMemberBS)
+		if !ok || len(x.Value) != len(y.Value) {
+			return false
+		}
+		xs, ys := make([]string, len(x.Value)), make([]string, len(y.Value))
+		for i := range x.Value {
+			xs[i], ys[i] = string(x.Value[i]), string(y.Value[i])
+		}
+		return sameMembers(xs, ys)
+	case *types.AttributeValueMemberL:
+		y, ok := b.(*types.AttributeValueMemberL)
+		if !ok || len(x.Value) != len(y.Value) {
+			return false
+		}
+		for i := range x.Value {
+			if !avEqual(x.Value[i], y.Value[i]) {
+				return false
+			}
+		}
+		return true
+	case *types.AttributeValueMemberM:
+		y, ok := b.(*types.AttributeValueMemberM)
+		if !ok || len(x.Value) != len(y.Value) {
+			return false
+		}
+		for k, v := range x.Value {
+			w, ok := y.Value[k]
+			if !ok || !avEqual(v, w) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// sameMembers reports whether a and b have the same members, in any order
+func sameMembers(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a = append([]string(nil), a...)
+	b = append([]string(nil), b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,167 @@
+package fuel
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/go-cmp/cmp"
+)
+
+type diffAddress struct {
+	City string
+	Zip  string
+}
+
+type diffItem struct {
+	ID      string `dynamodb:"id"`
+	Name    string
+	Count   int
+	Note    string   `dynamodb:",omitempty"`
+	Tags    []string `dynamodb:",set,omitempty"`
+	Dotted  string   `dynamodb:"a.b"`
+	Address diffAddress
+}
+
+func TestUpdateFromDiff(t *testing.T) {
+	base := diffItem{ID: "1", Name: "fuel", Count: 1, Note: "n", Tags: []string{"a", "b"}, Address: diffAddress{City: "Tokyo", Zip: "100"}}
+	tests := []struct {
+		name   string
+		old    diffItem
+		new    diffItem
+		opts   []DiffOption
+		update string
+		cond   string
+		names  map[string]string
+		values map[string]types.AttributeValue
+	}{
+		{
+			name: "no change",
+			old:  base,
+			new:  diffItem{ID: "1", Name: "fuel", Count: 1, Note: "n", Tags: []string{"b", "a"}, Address: diffAddress{City: "Tokyo", Zip: "100"}},
+		},
+		{
+			name: "set and remove",
+			old:  base,
+			new: func() diffItem {
+				v := base
+				v.Count = 2
+				v.Note = ""
+				v.Dotted = "x"
+				return v
+			}(),
+			update: "SET #n0 = :v0, #n1 = :v1 REMOVE Note",
+			cond:   "#n0 = :v2 AND Note = :v3 AND attribute_not_exists(#n1)",
+			names:  map[string]string{"#n0": "Count", "#n1": "a.b"},
+			values: map[string]types.AttributeValue{
+				":v0": &types.AttributeValueMemberN{Value: "2"},
+				":v1": &types.AttributeValueMemberS{Value: "x"},
+				":v2": &types.AttributeValueMemberN{Value: "1"},
+				":v3": &types.AttributeValueMemberS{Value: "n"},
+			},
+		},
+		{
+			name: "whole map",
+			old:  base,
+			new: func() diffItem {
+				v := base
+				v.Address.Zip = "200"
+				return v
+			}(),
+			update: "SET Address = :v0",
+			cond:   "Address = :v1",
+			values: map[string]types.AttributeValue{
+				":v0": &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+					"City": &types.AttributeValueMemberS{Value: "Tokyo"},
+					"Zip":  &types.AttributeValueMemberS{Value: "200"},
+				}},
+				":v1": &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+					"City": &types.AttributeValueMemberS{Value: "Tokyo"},
+					"Zip":  &types.AttributeValueMemberS{Value: "100"},
+				}},
+			},
+		},
+		{
+			name: "nested",
+			old:  base,
+			new: func() diffItem {
+				v := base
+				v.Address.Zip = "200"
+				v.Tags = []string{"c"}
+				return v
+			}(),
+			opts:   []DiffOption{DiffNested()},
+			update: "SET Address.Zip = :v0, Tags = :v1",
+			cond:   "Address.Zip = :v2 AND Tags = :v3",
+			values: map[string]types.AttributeValue{
+				":v0": &types.AttributeValueMemberS{Value: "200"},
+				":v1": &types.AttributeValueMemberSS{Value: []string{"c"}},
+				":v2": &types.AttributeValueMemberS{Value: "100"},
+				":v3": &types.AttributeValueMemberSS{Value: []string{"a", "b"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			update, cond, err := UpdateFromDiff(&tt.old, tt.new, tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.update == "" {
+				if !update.IsZero() || !cond.IsZero() {
+					t.Errorf("expected no changes, got %+v, %+v", update, cond)
+				}
+				return
+			}
+
+			var p Placeholders
+			updateExpr, err := update.Render(&p)
+			if err != nil {
+				t.Fatal(err)
+			}
+			condExpr, err := cond.Render(&p)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if updateExpr != tt.update {
+				t.Errorf("update missmatch: want %q, got %q", tt.update, updateExpr)
+			}
+			if condExpr != tt.cond {
+				t.Errorf("condition missmatch: want %q, got %q", tt.cond, condExpr)
+			}
+			if diff := cmp.Diff(tt.names, p.Names); diff != "" {
+				t.Errorf("names missmatch (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.values, p.Values); diff != "" {
+				t.Errorf("values missmatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+
+	if _, _, err := UpdateFromDiff(diffItem{}, diffAddress{}); err == nil {
+		t.Error("type mismatch: expected error, got nil")
+	}
+}
+
+func TestUpdateFromDiffKeys(t *testing.T) {
+	type keyed struct {
+		PK   string `dynamodb:"pk,hash"`
+		SK   int    `dynamodb:"sk,range"`
+		Name string
+	}
+	if _, _, err := UpdateFromDiff(keyed{PK: "a", SK: 1}, keyed{PK: "a", SK: 1, Name: "x"}); err != nil {
+		t.Errorf("same keys: unexpected error: %v", err)
+	}
+	if _, _, err := UpdateFromDiff(keyed{PK: "a", SK: 1}, keyed{PK: "b", SK: 1}); err == nil {
+		t.Error("changed hash key: expected error, got nil")
+	}
+	if _, _, err := UpdateFromDiff(keyed{PK: "a", SK: 1}, &keyed{PK: "a", SK: 2}); err == nil {
+		t.Error("changed range key: expected error, got nil")
+	}
+
+	type malformed struct {
+		ID string `dynamodb:"id,lsi=ByName"`
+	}
+	if _, _, err := UpdateFromDiff(malformed{}, malformed{}); err == nil {
+		t.Error("malformed key options: expected error, got nil")
+	}
+}
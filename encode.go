@@ -2,6 +2,7 @@ package fuel
 
 import (
 	"encoding"
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"strconv"
@@ -23,12 +24,61 @@ type ItemMarshaler interface {
 	MarshalDynamoDBItem() (map[string]types.AttributeValue, error)
 }
 
+// Encoder converts Go values into DynamoDB attribute values.
+// The zero value is not usable; create one with NewEncoder.
+type Encoder struct {
+	fieldOpts fieldOptions
+
+	hooks map[reflect.Type]EncodeHook
+}
+
+// EncoderOption configures an Encoder.
+type EncoderOption interface {
+	applyEncoder(e *Encoder)
+}
+
+type encoderOptionFunc func(*Encoder)
+
+func (fn encoderOptionFunc) applyEncoder(e *Encoder) {
+	fn(e)
+}
+
+// NewEncoder returns an Encoder configured with the given options.
+func NewEncoder(opts ...EncoderOption) *Encoder {
+	e := &Encoder{}
+	for _, opt := range opts {
+		opt.applyEncoder(e)
+	}
+	return e
+}
+
+var defaultEncoder = NewEncoder()
+
 // MarshalItem converts the given struct into a DynamoDB item
 func MarshalItem(v interface{}) (map[string]types.AttributeValue, error) {
-	return marshalItem(v)
+	return defaultEncoder.MarshalItem(v)
 }
 
-func marshalItem(v interface{}) (map[string]types.AttributeValue, error) {
+// Marshal converts the given value into a DynamoDB attribute value
+func Marshal(v interface{}) (types.AttributeValue, error) {
+	return defaultEncoder.Marshal(v)
+}
+
+// MarshalItem converts the given struct into a DynamoDB item
+func (e *Encoder) MarshalItem(v interface{}) (map[string]types.AttributeValue, error) {
+	item, err := e.marshalItem(v)
+	if err != nil {
+		return nil, err
+	}
+	return stampVersion(v, item), nil
+}
+
+// Marshal converts the given value into a DynamoDB attribute value
+func (e *Encoder) Marshal(v interface{}) (types.AttributeValue, error) {
+	return e.marshal(v, flagNone)
+}
+
+func (e *Encoder) marshalItem(v interface{}) (map[string]types.AttributeValue, error) {
 	switch x := v.(type) {
 	case map[string]types.AttributeValue:
 		return x, nil
@@ -43,18 +93,18 @@ func marshalItem(v interface{}) (map[string]types.AttributeValue, error) {
 
 	switch rv.Type().Kind() {
 	case reflect.Ptr:
-		return marshalItem(rv.Elem().Interface())
+		return e.marshalItem(rv.Elem().Interface())
 	case reflect.Struct:
-		return marshalStruct(rv)
+		return e.marshalStruct(rv)
 	case reflect.Map:
-		return marshalItemMap(rv.Interface())
+		return e.marshalItemMap(rv.Interface())
 	}
 	return nil, fmt.Errorf("dynamodb: marshal item: unsupported type %T: %v", rv.Interface(), rv.Interface())
 }
 
-func marshalItemMap(v interface{}) (map[string]types.AttributeValue, error) {
+func (e *Encoder) marshalItemMap(v interface{}) (map[string]types.AttributeValue, error) {
 	// TODO: maybe unify this with the map stuff in marshal
-	av, err := marshal(v, flagNone)
+	av, err := e.marshal(v, flagNone)
 	if err != nil {
 		return nil, err
 	}
@@ -65,73 +115,46 @@ func marshalItemMap(v interface{}) (map[string]types.AttributeValue, error) {
 	return avM.Value, nil
 }
 
-func marshalStruct(rv reflect.Value) (map[string]types.AttributeValue, error) {
+func (e *Encoder) marshalStruct(rv reflect.Value) (map[string]types.AttributeValue, error) {
+	fields, err := cachedFields(rv.Type(), e.fieldOpts)
+	if err != nil {
+		return nil, err
+	}
+
 	item := make(map[string]types.AttributeValue)
-	var err error
-
-	for i := 0; i < rv.Type().NumField(); i++ {
-		field := rv.Type().Field(i)
-		fv := rv.Field((i))
-
-		name, flags := fieldInfo(field)
-		omitempty := flags&flagOmitEmpty != 0
-		anonStruct := fv.Type().Kind() == reflect.Struct && field.Anonymous
-		anonStructPtr := fv.Type().Kind() == reflect.Ptr && fv.Type().Elem().Kind() == reflect.Struct && field.Anonymous
-		switch {
-		case !fv.CanInterface():
-			// kip unexported unembedded fields
-			if !anonStruct && !anonStructPtr {
-				continue
-			}
-		case name == "-":
+	for _, f := range fields.list {
+		fv, ok := fieldByIndex(rv, f.index)
+		if !ok {
+			// nil embedded pointer
 			continue
-		case omitempty:
-			if isZero(fv) {
-				continue
-			}
 		}
-
-		// embed anonymous structs
-		if anonStruct || anonStructPtr {
-			if anonStructPtr {
-				if fv.IsNil() {
-					continue
-				}
-				fv = fv.Elem()
-			}
-
-			avs, err := marshalStruct(fv)
-			if err != nil {
-				return nil, err
-			}
-			for k, v := range avs {
-				// don't clobber pre-sxisting fields
-				if _, ok := item[k]; ok {
-					continue
-				}
-				item[k] = v
-			}
+		if f.flags&flagOmitEmpty != 0 && isZero(fv) {
 			continue
 		}
 
-		av, err := marshal(fv.Interface(), flags)
+		av, err := e.marshal(fv.Interface(), f.flags)
 		if err != nil {
 			return nil, err
 		}
 		if av != nil {
-			item[name] = av
+			item[f.name] = av
 		}
 	}
-	return item, err
+	return item, nil
 }
 
-func marshal(v interface{}, flags encodeFlags) (types.AttributeValue, error) {
+func (e *Encoder) marshal(v interface{}, flags encodeFlags) (types.AttributeValue, error) {
+	// hooks take precedence over everything
+	if hook, ok := e.hooks[reflect.TypeOf(v)]; ok {
+		return hook(v)
+	}
+
 	// encoders with precedence over interfaces
 	if flags&flagUnixTime != 0 {
 		switch x := v.(type) {
 		case *time.Time:
 			if x != nil {
-				return marshal(*x, flags)
+				return e.marshal(*x, flags)
 			}
 		case time.Time:
 			if x.IsZero() {
@@ -149,6 +172,14 @@ func marshal(v interface{}, flags encodeFlags) (types.AttributeValue, error) {
 	switch x := v.(type) {
 	case types.AttributeValue:
 		return x, nil
+	case json.Number:
+		if x == "" {
+			if flags&flagNull != 0 {
+				return &types.AttributeValueMemberNULL{Value: true}, nil
+			}
+			return nil, nil
+		}
+		return &types.AttributeValueMemberN{Value: string(x)}, nil
 	case Marshaler:
 		if rv.Kind() == reflect.Ptr && rv.IsNil() {
 			if _, ok := rv.Type().Elem().MethodByName("MarshalDynamoDB"); ok {
@@ -187,7 +218,7 @@ func marshal(v interface{}, flags encodeFlags) (types.AttributeValue, error) {
 		}
 		return nil, nil
 	}
-	return marshalReflect(rv, flags)
+	return e.marshalReflect(rv, flags)
 }
 
 var (
@@ -195,7 +226,7 @@ var (
 	tmType = reflect.TypeOf(&nilTm).Elem()
 )
 
-func marshalReflect(rv reflect.Value, flags encodeFlags) (types.AttributeValue, error) {
+func (e *Encoder) marshalReflect(rv reflect.Value, flags encodeFlags) (types.AttributeValue, error) {
 	switch rv.Kind() {
 	case reflect.Ptr:
 		if rv.IsNil() {
@@ -204,7 +235,7 @@ func marshalReflect(rv reflect.Value, flags encodeFlags) (types.AttributeValue,
 			}
 			return nil, nil
 		}
-		return marshal(rv.Elem().Interface(), flags)
+		return e.marshal(rv.Elem().Interface(), flags)
 	case reflect.Bool:
 		return &types.AttributeValueMemberBOOL{Value: rv.Bool()}, nil
 	case reflect.Int, reflect.Int64, reflect.Int32, reflect.Int16, reflect.Int8:
@@ -274,7 +305,7 @@ func marshalReflect(rv reflect.Value, flags encodeFlags) (types.AttributeValue,
 			subFlags |= flagOmitEmpty
 		}
 		for _, key := range rv.MapKeys() {
-			v, err := marshal(rv.MapIndex(key).Interface(), subFlags)
+			v, err := e.marshal(rv.MapIndex(key).Interface(), subFlags)
 			if err != nil {
 				return nil, err
 			}
@@ -291,7 +322,7 @@ func marshalReflect(rv reflect.Value, flags encodeFlags) (types.AttributeValue,
 		}
 		return &types.AttributeValueMemberM{Value: avs}, nil
 	case reflect.Struct:
-		avs, err := marshalStruct(rv)
+		avs, err := e.marshalStruct(rv)
 		if err != nil {
 			return nil, err
 		}
@@ -341,7 +372,7 @@ func marshalReflect(rv reflect.Value, flags encodeFlags) (types.AttributeValue,
 		}
 		for i := 0; i < rv.Len(); i++ {
 			innerVal := rv.Index(i)
-			av, err := marshal(innerVal.Interface(), subFlags)
+			av, err := e.marshal(innerVal.Interface(), subFlags)
 			if err != nil {
 				return nil, err
 			}
@@ -600,8 +631,12 @@ func fieldInfo(field reflect.StructField) (name string, flags encodeFlags) {
 	if name == "" {
 		name = field.Name
 	}
+	return name, parseFlags(tags[1:])
+}
 
-	for _, t := range tags[1:] {
+// parseFlags parses dynamodb struct tag options, ignoring the ones that aren't flags
+func parseFlags(options []string) (flags encodeFlags) {
+	for _, t := range options {
 		switch t {
 		case "set":
 			flags |= flagSet
@@ -66,7 +66,7 @@ var itemEncodeOnlyTests = []struct {
 
 func TestMarshal(t *testing.T) {
 	for _, tc := range encodingTests {
-		got, err := marshal(tc.in, flagNone)
+		got, err := defaultEncoder.marshal(tc.in, flagNone)
 		if err != nil {
 			t.Errorf("%s: unexpected error: %v", tc.name, err)
 			continue
@@ -80,7 +80,7 @@ func TestMarshal(t *testing.T) {
 
 func TestMarshalItem(t *testing.T) {
 	for _, tc := range itemEncodingTests {
-		got, err := marshalItem(tc.in)
+		got, err := defaultEncoder.marshalItem(tc.in)
 		if err != nil {
 			t.Errorf("%s: unexpected error: %v", tc.name, err)
 			continue
@@ -94,7 +94,7 @@ func TestMarshalItem(t *testing.T) {
 
 func TestMarshalItemAsymmetric(t *testing.T) {
 	for _, tc := range itemEncodeOnlyTests {
-		got, err := marshalItem(tc.in)
+		got, err := defaultEncoder.marshalItem(tc.in)
 		if err != nil {
 			t.Errorf("%s: unexpected error: %v", tc.name, err)
 			continue
@@ -423,6 +423,18 @@ var itemEncodingTests = []struct {
 			"Embedded": &types.AttributeValueMemberBOOL{Value: true},
 		},
 	},
+	{
+		name: "nil exported pointer embedded struct",
+		in: struct {
+			*ExportedEmbedded
+			Other bool
+		}{
+			Other: true,
+		},
+		out: map[string]types.AttributeValue{
+			"Other": &types.AttributeValueMemberBOOL{Value: true},
+		},
+	},
 	{
 		name: "embedded struct clobber",
 		in: struct {
@@ -650,6 +662,14 @@ type ExportedEmbedded struct {
 	Embedded bool
 }
 
+type defaultEmbedded struct {
+	Unexported int `dynamodb:",default=1"`
+}
+
+type ExportedDefaultEmbedded struct {
+	Exported int `dynamodb:",default=1"`
+}
+
 type customMarshaler int
 
 func (cm customMarshaler) MarshalDynamoDB() (types.AttributeValue, error) {
@@ -0,0 +1,233 @@
+package fuel
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// Placeholders collects the ExpressionAttributeNames and ExpressionAttributeValues of a request.
+// Expressions of the same request (such as an update and its condition) should be
+// rendered with the same Placeholders, so that their placeholders don't collide.
+// The zero value is ready to use; the maps stay nil until a placeholder is needed.
+//
+// Attribute names that are reserved words or aren't plain identifiers (such as "Status",
+// "first-name" or "a.b") are written as # placeholders; other names are written as they are.
+type Placeholders struct {
+	Names  map[string]string
+	Values map[string]types.AttributeValue
+
+	// EscapeAll makes every attribute name a placeholder, even if it doesn't need one.
+	EscapeAll bool
+	// Encoder encodes values and describes the types of projections; nil means the default Encoder.
+	// Use the Encoder items are written with, so that values compare equal to stored attributes.
+	Encoder *Encoder
+
+	// placeholders of names added so far, by name
+	nameKeys map[string]string
+}
+
+// EscapePath returns how the attribute name or document path, such as "orders[2].sku",
+// is written in expressions, and the ExpressionAttributeNames it needs, if any.
+func EscapePath(path string) (string, map[string]string, error) {
+	var p Placeholders
+	expr, err := p.Path(path)
+	return expr, p.Names, err
+}
+
+// Name returns how the attribute name is written in expressions, adding a placeholder for it
+// if it needs one. The name is used as it is, even if it contains dots or brackets.
+// Placeholders are reused if the name was seen before.
+func (p *Placeholders) Name(name string) string {
+	if !p.EscapeAll && !needsPlaceholder(name) {
+		return name
+	}
+	if key, ok := p.nameKeys[name]; ok {
+		return key
+	}
+	if p.Names == nil {
+		p.Names = make(map[string]string)
+	}
+	if p.nameKeys == nil {
+		p.nameKeys = make(map[string]string)
+	}
+	key := nextKey("#n", len(p.Names), func(key string) bool {
+		_, used := p.Names[key]
+		return used
+	})
+	p.Names[key] = name
+	p.nameKeys[name] = key
+	return key
+}
+
+// Path returns how the document path, such as "orders[2].sku", is written in expressions,
+// adding placeholders for the names that need them.
+func (p *Placeholders) Path(path string) (string, error) {
+	elems, err := parsePath(path)
+	if err != nil {
+		return "", err
+	}
+	return p.pathElems(elems), nil
+}
+
+// pathElems is like Path, for a parsed path
+func (p *Placeholders) pathElems(elems []pathElem) string {
+	var b strings.Builder
+	for i, elem := range elems {
+		if elem.isIndex() {
+			b.WriteString("[" + strconv.Itoa(elem.index) + "]")
+			continue
+		}
+		if i > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(p.Name(elem.name))
+	}
+	return b.String()
+}
+
+// functions of the expression syntax, which aren't reserved words
+var expressionFunctions = map[string]bool{
+	"attribute_exists": true, "attribute_not_exists": true, "attribute_type": true, "begins_with": true,
+	"contains": true, "size": true, "if_not_exists": true, "list_append": true,
+}
+
+// needsPlaceholder reports whether name can't be written as it is in expressions
+func needsPlaceholder(name string) bool {
+	if name == "" || IsReservedWord(name) || expressionFunctions[strings.ToLower(name)] {
+		return true
+	}
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		letter := 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+		if !letter && (i == 0 || !isDigit(c) && c != '_') {
+			return true
+		}
+	}
+	return false
+}
+
+// value returns the placeholder for an encoded value
+func (p *Placeholders) value(av types.AttributeValue) string {
+	if p.Values == nil {
+		p.Values = make(map[string]types.AttributeValue)
+	}
+	key := nextKey(":v", len(p.Values), func(key string) bool {
+		_, used := p.Values[key]
+		return used
+	})
+	p.Values[key] = av
+	return key
+}
+
+func (p *Placeholders) encoder() *Encoder {
+	if p.Encoder != nil {
+		return p.Encoder
+	}
+	return defaultEncoder
+}
+
+// marshal encodes v like a struct field with the given flags and returns its placeholder
+func (p *Placeholders) marshal(v interface{}, flags encodeFlags) (string, error) {
+	av, err := p.encoder().marshal(v, flags)
+	if err != nil {
+		return "", err
+	}
+	if av == nil {
+		return "", fmt.Errorf("dynamodb: expression value %#v encodes to nothing", v)
+	}
+	return p.value(av), nil
+}
+
+// nextKey returns the first unused placeholder of the form prefix+n, starting at n
+func nextKey(prefix string, n int, used func(string) bool) string {
+	for {
+		key := prefix + strconv.Itoa(n)
+		if !used(key) {
+			return key
+		}
+		n++
+	}
+}
+
+// Operand is one side of a comparison in a condition: an attribute (see Name),
+// the size of an attribute (see Size) or a value (see Value).
+type Operand interface {
+	render(p *Placeholders) (string, error)
+}
+
+type nameOperand string
+
+// Name refers to an attribute, or to a document path such as "orders[2].sku".
+func Name(path string) Operand {
+	return nameOperand(path)
+}
+
+// AttributeName refers to the top-level attribute name, which is used as it is,
+// even if it contains dots or brackets.
+func AttributeName(name string) Operand {
+	return pathOperand{{name: name}}
+}
+
+func (n nameOperand) render(p *Placeholders) (string, error) {
+	return p.Path(string(n))
+}
+
+// pathOperand is a parsed document path, so its names can contain dots and brackets
+type pathOperand []pathElem
+
+func (po pathOperand) render(p *Placeholders) (string, error) {
+	return p.pathElems(po), nil
+}
+
+// String returns the path in document path syntax, for error messages
+func (po pathOperand) String() string {
+	return formatPath(po)
+}
+
+type sizeOperand string
+
+// Size refers to the size of an attribute or document path, using the size function.
+func Size(path string) Operand {
+	return sizeOperand(path)
+}
+
+func (s sizeOperand) render(p *Placeholders) (string, error) {
+	path, err := p.Path(string(s))
+	if err != nil {
+		return "", err
+	}
+	return "size(" + path + ")", nil
+}
+
+type valueOperand struct {
+	v     interface{}
+	flags encodeFlags
+}
+
+// Value is a Go value, encoded like fuel encodes struct fields tagged allowempty and null,
+// so that empty values and nil are kept.
+// Functions taking operands treat anything that isn't an Operand as a Value.
+func Value(v interface{}) Operand {
+	return valueOperand{v: v, flags: flagAllowEmpty | flagNull}
+}
+
+// AsSet is a Go slice or map encoded as a set, like struct fields tagged set.
+// Sets can't be empty.
+func AsSet(v interface{}) Operand {
+	return valueOperand{v: v, flags: flagSet}
+}
+
+func (v valueOperand) render(p *Placeholders) (string, error) {
+	return p.marshal(v.v, v.flags)
+}
+
+// toOperand wraps v with Value unless it is an Operand already
+func toOperand(v interface{}) Operand {
+	if op, ok := v.(Operand); ok {
+		return op
+	}
+	return Value(v)
+}
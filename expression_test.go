@@ -0,0 +1,116 @@
+package fuel
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPlaceholdersShared(t *testing.T) {
+	// placeholders already taken, e.g. by a hand-written expression
+	p := Placeholders{
+		Names:  map[string]string{"#n0": "pk"},
+		Values: map[string]types.AttributeValue{":v0": &types.AttributeValueMemberS{Value: "x"}},
+	}
+
+	first, err := Equal(Name("Status"), "active").Render(&p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := And(AttributeExists("Status"), LessThan(Name("Count"), 3)).Render(&p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "#n1 = :v1"; first != want {
+		t.Errorf("first expression missmatch: want %q, got %q", want, first)
+	}
+	if want := "attribute_exists(#n1) AND #n2 < :v2"; second != want {
+		t.Errorf("second expression missmatch: want %q, got %q", want, second)
+	}
+	wantNames := map[string]string{"#n0": "pk", "#n1": "Status", "#n2": "Count"}
+	if diff := cmp.Diff(wantNames, p.Names); diff != "" {
+		t.Errorf("names missmatch (-want, +got):\n%s", diff)
+	}
+	wantValues := map[string]types.AttributeValue{
+		":v0": &types.AttributeValueMemberS{Value: "x"},
+		":v1": &types.AttributeValueMemberS{Value: "active"},
+		":v2": &types.AttributeValueMemberN{Value: "3"},
+	}
+	if diff := cmp.Diff(wantValues, p.Values); diff != "" {
+		t.Errorf("values missmatch (-want, +got):\n%s", diff)
+	}
+}
+
+func TestEscapePath(t *testing.T) {
+	tests := []struct {
+		path       string
+		expr       string
+		names      map[string]string
+		shouldFail bool
+	}{
+		{path: "Price", expr: "Price"},
+		{path: "created_at", expr: "created_at"},
+		{path: "orders[2].sku", expr: "orders[2].sku"},
+		{path: "status", expr: "#n0", names: map[string]string{"#n0": "status"}},
+		{path: "TimeStamp", expr: "#n0", names: map[string]string{"#n0": "TimeStamp"}},
+		{path: "size", expr: "#n0", names: map[string]string{"#n0": "size"}},
+		{path: "begins_with", expr: "#n0", names: map[string]string{"#n0": "begins_with"}},
+		{path: "first-name", expr: "#n0", names: map[string]string{"#n0": "first-name"}},
+		{path: "2fa", expr: "#n0", names: map[string]string{"#n0": "2fa"}},
+		{path: "_id", expr: "#n0", names: map[string]string{"#n0": "_id"}},
+		{
+			path:  "data.items[0].name[1]",
+			expr:  "#n0.#n1[0].#n2[1]",
+			names: map[string]string{"#n0": "data", "#n1": "items", "#n2": "name"},
+		},
+		{path: "a..b", shouldFail: true},
+		{path: "a[x]", shouldFail: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			expr, names, err := EscapePath(tt.path)
+			if tt.shouldFail {
+				if err == nil {
+					t.Fatalf("expected error, got %q", expr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if expr != tt.expr {
+				t.Errorf("expression missmatch: want %q, got %q", tt.expr, expr)
+			}
+			if diff := cmp.Diff(tt.names, names); diff != "" {
+				t.Errorf("names missmatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPlaceholdersNames(t *testing.T) {
+	p := Placeholders{EscapeAll: true}
+	if got := p.Name("Price"); got != "#n0" {
+		t.Errorf("EscapeAll: want %q, got %q", "#n0", got)
+	}
+
+	p = Placeholders{}
+	cond, err := Equal(AttributeName("a.b[0]"), Name("a.b[0]")).Render(&p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "#n0 = a.b[0]"; cond != want {
+		t.Errorf("expression missmatch: want %q, got %q", want, cond)
+	}
+	if diff := cmp.Diff(map[string]string{"#n0": "a.b[0]"}, p.Names); diff != "" {
+		t.Errorf("names missmatch (-want, +got):\n%s", diff)
+	}
+
+	for name, want := range map[string]bool{"Status": true, "ttl": true, "zone": true, "Price": false, "": false} {
+		if got := IsReservedWord(name); got != want {
+			t.Errorf("IsReservedWord(%q): want %v, got %v", name, want, got)
+		}
+	}
+}
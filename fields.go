@@ -0,0 +1,269 @@
+package fuel
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// CodecOption configures how struct fields map to attributes.
+// It can be passed to both NewEncoder and NewDecoder, and should be
+// passed to both so that they agree on the layout of items.
+type CodecOption func(*fieldOptions)
+
+func (opt CodecOption) applyEncoder(e *Encoder) {
+	opt(&e.fieldOpts)
+}
+
+func (opt CodecOption) applyDecoder(d *Decoder) {
+	opt(&d.fieldOpts)
+}
+
+// fieldOptions holds the settings that affect the field layout of struct types
+type fieldOptions struct {
+	strict bool
+	naming *NamingStrategy
+}
+
+// DisallowAmbiguousFields makes encoding and decoding fail for struct types
+// in which embedded structs declare the same attribute at the same depth
+// without a tag to break the tie. By default such attributes are dropped, like encoding/json does.
+func DisallowAmbiguousFields() CodecOption {
+	return func(opts *fieldOptions) {
+		opts.strict = true
+	}
+}
+
+// field is a struct field, possibly promoted from an embedded struct, that holds an attribute
+type field struct {
+	name   string
+	tagged bool
+	// index of the field, as used by reflect.Value.FieldByIndex
+	index []int
+	flags encodeFlags
+	// value of the default= tag option
+	defaultValue string
+	hasDefault   bool
+	// alternative names accepted when decoding, from the alias= tag option
+	aliases []string
+}
+
+// structFields describes how the attributes of an item map to the fields of a struct type
+type structFields struct {
+	// fields in declaration order
+	list []field
+	err  error
+}
+
+type fieldsKey struct {
+	rt   reflect.Type
+	opts fieldOptions
+}
+
+var fieldCache sync.Map // map[fieldsKey]*structFields
+
+// cachedFields is like fieldsInStruct but caches the result per type and options
+func cachedFields(rt reflect.Type, opts fieldOptions) (*structFields, error) {
+	key := fieldsKey{rt: rt, opts: opts}
+	if f, ok := fieldCache.Load(key); ok {
+		return f.(*structFields), f.(*structFields).err
+	}
+	f, _ := fieldCache.LoadOrStore(key, fieldsInStruct(rt, opts))
+	return f.(*structFields), f.(*structFields).err
+}
+
+// fieldsInStruct returns the fields of a struct type, including those promoted from embedded structs.
+// Conflicting attribute names are resolved like encoding/json:
+// the shallowest field wins, then a tagged field wins over untagged ones,
+// and any remaining tie is dropped (or reported, if strict).
+// Unlike encoding/json, embedded structs are flattened even if their tag gives them a name.
+func fieldsInStruct(rt reflect.Type, opts fieldOptions) *structFields {
+	var fields []field
+
+	// breadth-first search over embedded structs
+	current := []field{}
+	next := []field{{index: nil}}
+	nextTypes := []reflect.Type{rt}
+	var count, nextCount map[reflect.Type]int
+	visited := make(map[reflect.Type]bool)
+
+	for len(next) > 0 {
+		current, next = next, current[:0]
+		currentTypes := nextTypes
+		nextTypes = nil
+		count, nextCount = nextCount, make(map[reflect.Type]int)
+
+		for n, f := range current {
+			ft := currentTypes[n]
+			if visited[ft] {
+				continue
+			}
+			visited[ft] = true
+
+			for i := 0; i < ft.NumField(); i++ {
+				sf := ft.Field(i)
+				if sf.Anonymous {
+					t := sf.Type
+					if t.Kind() == reflect.Ptr {
+						t = t.Elem()
+					}
+					// unexported embedded non-structs are ignored
+					if sf.PkgPath != "" && t.Kind() != reflect.Struct {
+						continue
+					}
+				} else if sf.PkgPath != "" {
+					// unexported fields are ignored
+					continue
+				}
+
+				name, flags := fieldInfo(sf)
+				if name == "-" {
+					continue
+				}
+				tagged := strings.Split(sf.Tag.Get("dynamodb"), ",")[0] != ""
+				if !tagged && opts.naming != nil {
+					name = opts.naming.convert(name)
+				}
+
+				index := make([]int, len(f.index)+1)
+				copy(index, f.index)
+				index[len(f.index)] = i
+
+				typ := sf.Type
+				if typ.Name() == "" && typ.Kind() == reflect.Ptr {
+					typ = typ.Elem()
+				}
+
+				if !sf.Anonymous || typ.Kind() != reflect.Struct {
+					def, hasDef := tagOption(sf, "default")
+					var aliases []string
+					if alias, ok := tagOption(sf, "alias"); ok && alias != "" {
+						aliases = strings.Split(alias, "|")
+					}
+					fields = append(fields, field{
+						name:         name,
+						tagged:       tagged,
+						index:        index,
+						flags:        flags,
+						defaultValue: def,
+						hasDefault:   hasDef,
+						aliases:      aliases,
+					})
+					if count[ft] > 1 {
+						// embedded more than once at this depth: add a duplicate
+						// so the conflict resolution below drops it
+						fields = append(fields, fields[len(fields)-1])
+					}
+					continue
+				}
+
+				// embedded struct, search its fields next
+				nextCount[typ]++
+				if nextCount[typ] == 1 {
+					next = append(next, field{index: index})
+					nextTypes = append(nextTypes, typ)
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(fields, func(i, j int) bool {
+		x := fields
+		if x[i].name != x[j].name {
+			return x[i].name < x[j].name
+		}
+		if len(x[i].index) != len(x[j].index) {
+			return len(x[i].index) < len(x[j].index)
+		}
+		if x[i].tagged != x[j].tagged {
+			return x[i].tagged
+		}
+		return indexLess(x[i].index, x[j].index)
+	})
+
+	sf := &structFields{}
+	for i := 0; i < len(fields); {
+		// all fields with the same name
+		j := i + 1
+		for j < len(fields) && fields[j].name == fields[i].name {
+			j++
+		}
+		dominant, ok := dominantField(fields[i:j])
+		if ok {
+			sf.list = append(sf.list, dominant)
+		} else if opts.strict && sf.err == nil {
+			sf.err = fmt.Errorf("dynamodb: %s: ambiguous attribute %q declared by multiple embedded structs", rt, fields[i].name)
+		}
+		i = j
+	}
+
+	sort.Slice(sf.list, func(i, j int) bool {
+		return indexLess(sf.list[i].index, sf.list[j].index)
+	})
+	return sf
+}
+
+// dominantField picks the field that wins among fields of the same name, which are
+// sorted by depth and tagged-ness. It reports false if there is no single winner.
+func dominantField(fields []field) (field, bool) {
+	if len(fields) > 1 && len(fields[0].index) == len(fields[1].index) && fields[0].tagged == fields[1].tagged {
+		return field{}, false
+	}
+	return fields[0], true
+}
+
+func indexLess(a, b []int) bool {
+	for k, x := range a {
+		if k >= len(b) {
+			return false
+		}
+		if x != b[k] {
+			return x < b[k]
+		}
+	}
+	return len(a) < len(b)
+}
+
+// fieldByIndex is like reflect.Value.FieldByIndex, but reports false
+// instead of panicking if it runs into a nil embedded pointer
+func fieldByIndex(rv reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && rv.Kind() == reflect.Ptr {
+			if rv.IsNil() {
+				return reflect.Value{}, false
+			}
+			rv = rv.Elem()
+		}
+		rv = rv.Field(x)
+	}
+	return rv, true
+}
+
+// allocFieldByIndex is like fieldByIndex, but allocates nil embedded pointers on the way.
+// It reports false if a pointer can't be allocated because it is unexported.
+func allocFieldByIndex(rv reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && rv.Kind() == reflect.Ptr {
+			if rv.IsNil() {
+				if !rv.CanSet() {
+					return reflect.Value{}, false
+				}
+				rv.Set(reflect.New(rv.Type().Elem()))
+			}
+			rv = rv.Elem()
+		}
+		rv = rv.Field(x)
+	}
+	return rv, true
+}
+
+// indirectType returns the type of v with any pointers removed
+func indirectType(v interface{}) reflect.Type {
+	rt := reflect.TypeOf(v)
+	for rt != nil && rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+	return rt
+}
@@ -0,0 +1,167 @@
+package fuel
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/go-cmp/cmp"
+)
+
+type conflictA struct {
+	Name string
+	A    int
+}
+
+type conflictB struct {
+	Name string
+	B    int
+}
+
+type conflictTagged struct {
+	Title string `dynamodb:"Name"`
+}
+
+type conflictDeep struct {
+	conflictA
+}
+
+type recursiveEmbed struct {
+	*recursiveEmbed
+	ID string
+}
+
+var embeddingConflictTests = []struct {
+	name string
+	in   interface{}
+	out  map[string]types.AttributeValue
+}{
+	{
+		name: "ambiguous fields are dropped",
+		in: struct {
+			conflictA
+			conflictB
+		}{
+			conflictA: conflictA{Name: "a", A: 1},
+			conflictB: conflictB{Name: "b", B: 2},
+		},
+		out: map[string]types.AttributeValue{
+			"A": &types.AttributeValueMemberN{Value: "1"},
+			"B": &types.AttributeValueMemberN{Value: "2"},
+		},
+	},
+	{
+		name: "tagged beats untagged",
+		in: struct {
+			conflictA
+			conflictTagged
+		}{
+			conflictA:      conflictA{Name: "a", A: 1},
+			conflictTagged: conflictTagged{Title: "tagged"},
+		},
+		out: map[string]types.AttributeValue{
+			"Name": &types.AttributeValueMemberS{Value: "tagged"},
+			"A":    &types.AttributeValueMemberN{Value: "1"},
+		},
+	},
+	{
+		name: "shallowest wins",
+		in: struct {
+			conflictDeep
+			conflictB
+		}{
+			conflictDeep: conflictDeep{conflictA: conflictA{Name: "deep", A: 1}},
+			conflictB:    conflictB{Name: "b", B: 2},
+		},
+		out: map[string]types.AttributeValue{
+			"Name": &types.AttributeValueMemberS{Value: "b"},
+			"A":    &types.AttributeValueMemberN{Value: "1"},
+			"B":    &types.AttributeValueMemberN{Value: "2"},
+		},
+	},
+	{
+		name: "tagged embedded struct is still flattened",
+		in: struct {
+			ExportedEmbedded `dynamodb:"sub"`
+		}{
+			ExportedEmbedded: ExportedEmbedded{Embedded: true},
+		},
+		out: map[string]types.AttributeValue{
+			"Embedded": &types.AttributeValueMemberBOOL{Value: true},
+		},
+	},
+	{
+		name: "recursive embedding",
+		in:   recursiveEmbed{ID: "x"},
+		out: map[string]types.AttributeValue{
+			"ID": &types.AttributeValueMemberS{Value: "x"},
+		},
+	},
+}
+
+func TestEmbeddingConflicts(t *testing.T) {
+	for _, tc := range embeddingConflictTests {
+		got, err := MarshalItem(tc.in)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+			continue
+		}
+		if diff := cmp.Diff(tc.out, got); diff != "" {
+			t.Errorf("%s: encode missmatch (-want, +got):\n%s", tc.name, diff)
+		}
+	}
+}
+
+func TestEmbeddingConflictsDecode(t *testing.T) {
+	item := map[string]types.AttributeValue{
+		"Name": &types.AttributeValueMemberS{Value: "x"},
+		"A":    &types.AttributeValueMemberN{Value: "1"},
+	}
+
+	var ambiguous struct {
+		conflictA
+		conflictB
+	}
+	if err := UnmarshalItem(item, &ambiguous); err != nil {
+		t.Fatal(err)
+	}
+	if ambiguous.conflictA.Name != "" || ambiguous.conflictB.Name != "" || ambiguous.A != 1 {
+		t.Errorf("ambiguous field was decoded: %+v", ambiguous)
+	}
+
+	var shallow struct {
+		conflictDeep
+		conflictB
+	}
+	if err := UnmarshalItem(item, &shallow); err != nil {
+		t.Fatal(err)
+	}
+	if shallow.conflictB.Name != "x" || shallow.conflictDeep.Name != "" {
+		t.Errorf("shallowest field was not decoded: %+v", shallow)
+	}
+}
+
+func TestDisallowAmbiguousFields(t *testing.T) {
+	type ambiguous struct {
+		conflictA
+		conflictB
+	}
+
+	enc := NewEncoder(DisallowAmbiguousFields())
+	if _, err := enc.MarshalItem(ambiguous{}); err == nil {
+		t.Error("encode: expected error, got nil")
+	}
+	dec := NewDecoder(DisallowAmbiguousFields())
+	if err := dec.UnmarshalItem(map[string]types.AttributeValue{}, &ambiguous{}); err == nil {
+		t.Error("decode: expected error, got nil")
+	}
+
+	// resolvable conflicts are fine
+	type resolved struct {
+		Name string
+		conflictA
+		conflictB
+	}
+	if _, err := enc.MarshalItem(resolved{}); err != nil {
+		t.Errorf("encode: unexpected error: %v", err)
+	}
+}
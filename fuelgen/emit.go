@@ -0,0 +1,620 @@
+package fuelgen
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type kind int
+
+const (
+	// kindOther is encoded and decoded with reflection, by calling into package fuel
+	kindOther kind = iota
+	kindString
+	kindBool
+	kindInt
+	kindUint
+	kindFloat
+	kindBytes
+	kindTime
+	// kindStruct is a struct type that has generated methods
+	kindStruct
+)
+
+func (k kind) scalar() bool {
+	return k >= kindString && k <= kindFloat
+}
+
+// typeInfo describes how generated code handles a field type
+type typeInfo struct {
+	kind kind
+	// Go source of the type (of the elements, for slices and pointers)
+	expr string
+	// import needed by expr, if any
+	importName, importPath string
+	// size of numbers, for parsing default values
+	bits int
+	// pointer to kind
+	ptr bool
+	// slice of kind
+	slice bool
+}
+
+var basicTypes = map[string]typeInfo{
+	"string":  {kind: kindString},
+	"bool":    {kind: kindBool},
+	"int":     {kind: kindInt, bits: strconv.IntSize},
+	"int8":    {kind: kindInt, bits: 8},
+	"int16":   {kind: kindInt, bits: 16},
+	"int32":   {kind: kindInt, bits: 32},
+	"rune":    {kind: kindInt, bits: 32},
+	"int64":   {kind: kindInt, bits: 64},
+	"uint":    {kind: kindUint, bits: strconv.IntSize},
+	"uint8":   {kind: kindUint, bits: 8},
+	"byte":    {kind: kindUint, bits: 8},
+	"uint16":  {kind: kindUint, bits: 16},
+	"uint32":  {kind: kindUint, bits: 32},
+	"uint64":  {kind: kindUint, bits: 64},
+	"float32": {kind: kindFloat, bits: 32},
+	"float64": {kind: kindFloat, bits: 64},
+}
+
+// codecMethods change how fuel encodes or decodes a type
+var codecMethods = []string{"MarshalDynamoDB", "UnmarshalDynamoDB", "MarshalText", "UnmarshalText"}
+
+type generator struct {
+	info *pkgInfo
+	// types that get generated methods
+	generate map[string]bool
+	// imports used by the generated code, by local name
+	imports map[string]string
+	buf     bytes.Buffer
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+// classify determines how generated code handles values of type expr
+func (g *generator) classify(expr ast.Expr, imports map[string]string) typeInfo {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		elem := g.classify(t.X, imports)
+		if !elem.scalarOr(kindTime, kindStruct) {
+			return typeInfo{}
+		}
+		elem.ptr = true
+		return elem
+	case *ast.ArrayType:
+		if t.Len != nil {
+			return typeInfo{}
+		}
+		if ident, ok := t.Elt.(*ast.Ident); ok && (ident.Name == "byte" || ident.Name == "uint8") && g.info.types[ident.Name] == nil {
+			return typeInfo{kind: kindBytes}
+		}
+		elem := g.classify(t.Elt, imports)
+		if !elem.scalarOr() {
+			return typeInfo{}
+		}
+		elem.slice = true
+		return elem
+	case *ast.Ident:
+		if basic, ok := basicTypes[t.Name]; ok && g.info.types[t.Name] == nil {
+			basic.expr = t.Name
+			return basic
+		}
+		return g.named(t.Name)
+	case *ast.SelectorExpr:
+		pkg, ok := t.X.(*ast.Ident)
+		if !ok || imports[pkg.Name] != "time" {
+			return typeInfo{}
+		}
+		switch t.Sel.Name {
+		case "Time":
+			return typeInfo{kind: kindTime}
+		case "Duration":
+			return typeInfo{kind: kindInt, bits: 64, expr: pkg.Name + ".Duration", importName: pkg.Name, importPath: "time"}
+		}
+	}
+	return typeInfo{}
+}
+
+// scalarOr reports whether ti is a plain value of a scalar kind or one of kinds
+func (ti typeInfo) scalarOr(kinds ...kind) bool {
+	if ti.ptr || ti.slice {
+		return false
+	}
+	if ti.kind.scalar() {
+		return true
+	}
+	for _, k := range kinds {
+		if ti.kind == k {
+			return true
+		}
+	}
+	return false
+}
+
+// named classifies a type declared in the package
+func (g *generator) named(name string) typeInfo {
+	decl, ok := g.info.types[name]
+	if !ok {
+		return typeInfo{}
+	}
+	if _, _, ok := g.info.structType(name); ok {
+		if g.generate[name] && !g.hasCodecMethods(name, make(map[string]bool)) {
+			return typeInfo{kind: kindStruct, expr: name}
+		}
+		return typeInfo{}
+	}
+	if len(g.info.methods[name]) > 0 {
+		return typeInfo{}
+	}
+	ti := g.classify(decl.spec.Type, decl.imports)
+	if !ti.scalarOr() {
+		return typeInfo{}
+	}
+	ti.expr, ti.importName, ti.importPath = name, "", ""
+	return ti
+}
+
+// hasCodecMethods reports whether the struct type name declares or promotes one of codecMethods
+func (g *generator) hasCodecMethods(name string, seen map[string]bool) bool {
+	if seen[name] {
+		return false
+	}
+	seen[name] = true
+	for _, m := range codecMethods {
+		if g.info.methods[name][m] {
+			return true
+		}
+	}
+	st, _, ok := g.info.structType(name)
+	if !ok {
+		return false
+	}
+	for _, f := range st.Fields.List {
+		if len(f.Names) > 0 {
+			continue
+		}
+		if _, typeName, _ := embeddedName(f.Type); typeName == "" || g.hasCodecMethods(typeName, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+// convert returns the Go source converting x to the basic type to, if needed
+func convert(to string, ti typeInfo, x string) string {
+	if ti.expr == to {
+		return x
+	}
+	return to + "(" + x + ")"
+}
+
+// convertFrom returns the Go source converting x of basic type from to the type of ti
+func (g *generator) convertFrom(from string, ti typeInfo, x string) string {
+	if ti.expr == from {
+		return x
+	}
+	if ti.importPath != "" {
+		g.imports[ti.importName] = ti.importPath
+	}
+	return ti.expr + "(" + x + ")"
+}
+
+// scalarAV returns the Go source of an attribute value holding x, a value of a scalar kind
+func scalarAV(ti typeInfo, x string) string {
+	switch ti.kind {
+	case kindString:
+		return "&types.AttributeValueMemberS{Value: " + convert("string", ti, x) + "}"
+	case kindBool:
+		return "&types.AttributeValueMemberBOOL{Value: " + convert("bool", ti, x) + "}"
+	case kindInt:
+		return "&types.AttributeValueMemberN{Value: strconv.FormatInt(" + convert("int64", ti, x) + ", 10)}"
+	case kindUint:
+		return "&types.AttributeValueMemberN{Value: strconv.FormatUint(" + convert("uint64", ti, x) + ", 10)}"
+	default:
+		return "&types.AttributeValueMemberN{Value: strconv.FormatFloat(" + convert("float64", ti, x) + ", 'f', -1, 64)}"
+	}
+}
+
+// scalarString returns the Go source of the set element string for x, a value of a scalar kind
+func scalarString(ti typeInfo, x string) string {
+	switch ti.kind {
+	case kindString:
+		return convert("string", ti, x)
+	case kindInt:
+		return "strconv.FormatInt(" + convert("int64", ti, x) + ", 10)"
+	case kindUint:
+		return "strconv.FormatUint(" + convert("uint64", ti, x) + ", 10)"
+	default:
+		return "strconv.FormatFloat(" + convert("float64", ti, x) + ", 'f', -1, 64)"
+	}
+}
+
+// nonZero returns a Go condition that holds if x, a value of a scalar kind, isn't the zero value
+func nonZero(ti typeInfo, x string) string {
+	switch ti.kind {
+	case kindString:
+		return x + ` != ""`
+	case kindBool:
+		return x
+	}
+	return x + " != 0"
+}
+
+const avNULL = "&types.AttributeValueMemberNULL{Value: true}"
+
+// branch is one case of an if/else chain; an empty cond is the final else
+type branch struct {
+	cond string
+	body string
+}
+
+func chain(branches ...branch) string {
+	var b strings.Builder
+	for i, br := range branches {
+		switch {
+		case i == 0 && br.cond == "":
+			return br.body
+		case i == 0:
+			fmt.Fprintf(&b, "if %s {\n%s", br.cond, br.body)
+		case br.cond == "":
+			fmt.Fprintf(&b, "} else {\n%s", br.body)
+		default:
+			fmt.Fprintf(&b, "} else if %s {\n%s", br.cond, br.body)
+		}
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// flags are the encoding flags of a field's struct tag
+type flags struct {
+	set, omitEmpty, omitEmptyElem, allowEmpty, null, unixTime bool
+	// all options, passed on to fuel.MarshalField
+	options string
+}
+
+func fieldFlags(f genField) flags {
+	return flags{
+		set:           f.has("set"),
+		omitEmpty:     f.has("omitempty"),
+		omitEmptyElem: f.has("omitemptyelem"),
+		allowEmpty:    f.has("allowempty"),
+		null:          f.has("null"),
+		unixTime:      f.has("unixtime"),
+		options:       strings.Join(f.options, ","),
+	}
+}
+
+// access returns the Go source of the field at path, relative to v
+func access(path []step) string {
+	names := make([]string, 0, len(path)+1)
+	names = append(names, "v")
+	for _, s := range path {
+		names = append(names, s.name)
+	}
+	return strings.Join(names, ".")
+}
+
+// guards returns the conditions under which the embedded pointers on the way to a field are all non-nil
+func guards(path []step) []string {
+	var conds []string
+	for i, s := range path[:len(path)-1] {
+		if s.ptr {
+			conds = append(conds, access(path[:i+1])+" != nil")
+		}
+	}
+	return conds
+}
+
+func (g *generator) marshalFunc(name string, fields []genField) {
+	g.printf("// MarshalDynamoDBItem implements fuel.ItemMarshaler.\n")
+	g.printf("func (v *%s) MarshalDynamoDBItem() (map[string]types.AttributeValue, error) {\n", name)
+	g.printf("item := make(map[string]types.AttributeValue, %d)\n", len(fields))
+	for _, f := range fields {
+		code := g.marshalField(f)
+		if conds := guards(f.path); len(conds) > 0 {
+			code = chain(branch{cond: strings.Join(conds, " && "), body: code})
+		}
+		g.printf("%s", code)
+	}
+	g.printf("return item, nil\n}\n\n")
+}
+
+// marshalField returns the Go source that adds the attribute for f to item
+func (g *generator) marshalField(f genField) string {
+	x := access(f.path)
+	key := strconv.Quote(f.name)
+	fl := fieldFlags(f)
+	ti := g.classify(f.typ, f.imports)
+	set := func(av string) string {
+		return "item[" + key + "] = " + av + "\n"
+	}
+
+	switch {
+	case ti.kind == kindOther,
+		ti.kind == kindStruct && !ti.ptr && fl.omitEmpty,
+		ti.slice && fl.set && ti.kind == kindBool:
+		return fmt.Sprintf("if av, err := fuel.MarshalField(&%s, %q); err != nil {\nreturn nil, err\n} else if av != nil {\n%s}\n",
+			x, fl.options, set("av"))
+
+	case ti.slice && fl.set:
+		member := "SS"
+		if ti.kind != kindString {
+			member = "NS"
+		}
+		var elem string
+		if fl.omitEmptyElem {
+			elem = chain(branch{cond: nonZero(ti, "elem"), body: "ss = append(ss, " + scalarString(ti, "elem") + ")\n"})
+		} else {
+			elem = "ss = append(ss, " + scalarString(ti, "elem") + ")\n"
+		}
+		body := fmt.Sprintf("ss := make([]string, 0, len(%s))\nfor _, elem := range %s {\n%s}\n", x, x, elem) +
+			chain(branch{cond: "len(ss) != 0", body: set("&types.AttributeValueMember" + member + "{Value: ss}")})
+		if fl.null && !fl.omitEmpty {
+			return chain(branch{cond: x + " == nil", body: set(avNULL)}, branch{cond: "len(" + x + ") != 0", body: body})
+		}
+		return chain(branch{cond: "len(" + x + ") != 0", body: body})
+
+	case ti.slice:
+		var elem string
+		switch {
+		case ti.kind == kindString && fl.omitEmptyElem:
+			elem = chain(branch{cond: nonZero(ti, "elem"), body: "avs = append(avs, " + scalarAV(ti, "elem") + ")\n"})
+		case ti.kind == kindString:
+			elem = chain(
+				branch{cond: nonZero(ti, "elem"), body: "avs = append(avs, " + scalarAV(ti, "elem") + ")\n"},
+				branch{body: `avs = append(avs, &types.AttributeValueMemberS{Value: ""})` + "\n"},
+			)
+		default:
+			elem = "avs = append(avs, " + scalarAV(ti, "elem") + ")\n"
+		}
+		body := fmt.Sprintf("avs := make([]types.AttributeValue, 0, len(%s))\nfor _, elem := range %s {\n%s}\n", x, x, elem)
+		if fl.omitEmpty {
+			body += chain(branch{cond: "len(avs) != 0", body: set("&types.AttributeValueMemberL{Value: avs}")})
+			return chain(branch{cond: x + " != nil", body: body})
+		}
+		body += set("&types.AttributeValueMemberL{Value: avs}")
+		if fl.null {
+			return chain(branch{cond: x + " == nil", body: set(avNULL)}, branch{body: body})
+		}
+		return "{\n" + body + "}\n"
+
+	case ti.kind == kindBytes:
+		branches := []branch{{cond: "len(" + x + ") != 0", body: set("&types.AttributeValueMemberB{Value: " + x + "}")}}
+		if fl.null && !fl.omitEmpty {
+			branches = append(branches, branch{cond: x + " == nil", body: set(avNULL)})
+		}
+		if fl.allowEmpty {
+			cond := ""
+			if fl.omitEmpty {
+				cond = x + " != nil"
+			}
+			branches = append(branches, branch{cond: cond, body: set("&types.AttributeValueMemberB{Value: []byte{}}")})
+		}
+		return chain(branches...)
+
+	case ti.kind == kindTime:
+		var body string
+		if fl.unixTime {
+			body = chain(branch{cond: "!" + x + ".IsZero()", body: set("&types.AttributeValueMemberN{Value: strconv.FormatInt(" + x + ".Unix(), 10)}")})
+		} else {
+			body = fmt.Sprintf("if text, err := %s.MarshalText(); err != nil {\nreturn nil, err\n} else {\n%s}\n",
+				x, set("&types.AttributeValueMemberS{Value: string(text)}"))
+		}
+		switch {
+		case !ti.ptr && fl.omitEmpty && !fl.unixTime:
+			return chain(branch{cond: "!" + x + ".IsZero()", body: body})
+		case !ti.ptr:
+			return body
+		case fl.omitEmpty && !fl.unixTime:
+			return chain(branch{cond: x + " != nil && !" + x + ".IsZero()", body: body})
+		case fl.null && !fl.omitEmpty:
+			return chain(branch{cond: x + " != nil", body: body}, branch{body: set(avNULL)})
+		}
+		return chain(branch{cond: x + " != nil", body: body})
+
+	case ti.kind == kindStruct:
+		body := fmt.Sprintf("if sub, err := %s.MarshalDynamoDBItem(); err != nil {\nreturn nil, err\n} else {\n%s}\n",
+			x, set("&types.AttributeValueMemberM{Value: sub}"))
+		if !ti.ptr {
+			return body
+		}
+		if fl.null && !fl.omitEmpty {
+			return chain(branch{cond: x + " != nil", body: body}, branch{body: set(avNULL)})
+		}
+		return chain(branch{cond: x + " != nil", body: body})
+
+	case ti.ptr:
+		inner := fl
+		inner.omitEmpty = false
+		body := g.marshalScalar(ti, "*"+x, inner, set)
+		if fl.null && !fl.omitEmpty {
+			return chain(branch{cond: x + " != nil", body: body}, branch{body: set(avNULL)})
+		}
+		return chain(branch{cond: x + " != nil", body: body})
+	}
+
+	return g.marshalScalar(ti, x, fl, set)
+}
+
+// marshalScalar returns the Go source that encodes x, a value of a scalar kind
+func (g *generator) marshalScalar(ti typeInfo, x string, fl flags, set func(string) string) string {
+	if fl.omitEmpty {
+		return chain(branch{cond: nonZero(ti, x), body: set(scalarAV(ti, x))})
+	}
+	if ti.kind != kindString {
+		return set(scalarAV(ti, x))
+	}
+	branches := []branch{{cond: nonZero(ti, x), body: set(scalarAV(ti, x))}}
+	switch {
+	case fl.allowEmpty:
+		branches = append(branches, branch{body: set(`&types.AttributeValueMemberS{Value: ""}`)})
+	case fl.null:
+		branches = append(branches, branch{body: set(avNULL)})
+	}
+	return chain(branches...)
+}
+
+func (g *generator) unmarshalFunc(name string, fields []genField) error {
+	g.printf("// UnmarshalDynamoDBItem implements fuel.ItemUnmarshaler.\n")
+	g.printf("func (v *%s) UnmarshalDynamoDBItem(item map[string]types.AttributeValue) error {\n", name)
+	g.printf("*v = %s{}\n", name)
+	g.printf("var err error\n")
+
+	var defaults []genField
+	for _, f := range fields {
+		if !settable(f.path) {
+			// behind an unexported embedded pointer, which can't be allocated
+			continue
+		}
+		if _, ok := f.option("default"); ok {
+			defaults = append(defaults, f)
+		}
+		g.printf("%s", g.unmarshalField(f))
+	}
+
+	// embedded pointers are allocated only if one of their attributes is present,
+	// so apply defaults after everything else
+	for _, f := range defaults {
+		body, err := g.defaultValue(f)
+		if err != nil {
+			return err
+		}
+		cond := append([]string{"!ok"}, guards(f.path)...)
+		aliases := f.aliases()
+		if len(aliases) == 0 {
+			g.printf("if _, ok := item[%q]; %s {\n%s}\n", f.name, strings.Join(cond, " && "), body)
+			continue
+		}
+		g.printf("{\n_, ok := item[%q]\n", f.name)
+		for _, alias := range aliases {
+			g.printf("if !ok {\n_, ok = item[%q]\n}\n", alias)
+		}
+		g.printf("if %s {\n%s}\n}\n", strings.Join(cond, " && "), body)
+	}
+
+	g.printf("if err != nil {\nreturn err\n}\n")
+	g.printf("if defaulter, ok := interface{}(v).(fuel.Defaulter); ok {\ndefaulter.SetDefaults()\n}\n")
+	g.printf("return nil\n}\n\n")
+	return nil
+}
+
+// settable reports whether the field at path can be reached without going through an unexported embedded pointer
+func settable(path []step) bool {
+	for _, s := range path[:len(path)-1] {
+		if s.ptr && !s.exported {
+			return false
+		}
+	}
+	return true
+}
+
+// unmarshalField returns the Go source that decodes the attribute for f, if present
+func (g *generator) unmarshalField(f genField) string {
+	x := access(f.path)
+	ti := g.classify(f.typ, f.imports)
+
+	var body strings.Builder
+	for i, s := range f.path[:len(f.path)-1] {
+		if s.ptr {
+			p := access(f.path[:i+1])
+			fmt.Fprintf(&body, "if %s == nil {\n%s = new(%s)\n}\n", p, p, s.typeName)
+		}
+	}
+
+	fallback := fmt.Sprintf("if e := fuel.Unmarshal(av, &%s); e != nil {\nerr = e\n}\n", x)
+	var member, decode string
+	switch {
+	case !ti.scalarOr(kindStruct):
+	case ti.kind == kindString:
+		member, decode = "S", x+" = "+g.convertFrom("string", ti, "x.Value")+"\n"
+	case ti.kind == kindBool:
+		member, decode = "BOOL", x+" = "+g.convertFrom("bool", ti, "x.Value")+"\n"
+	case ti.kind == kindInt:
+		member = "N"
+		decode = fmt.Sprintf("if n, e := strconv.ParseInt(x.Value, 10, 64); e != nil {\nerr = e\n} else {\n%s = %s\n}\n", x, g.convertFrom("int64", ti, "n"))
+	case ti.kind == kindUint:
+		member = "N"
+		decode = fmt.Sprintf("if n, e := strconv.ParseUint(x.Value, 10, 64); e != nil {\nerr = e\n} else {\n%s = %s\n}\n", x, g.convertFrom("uint64", ti, "n"))
+	case ti.kind == kindFloat:
+		member = "N"
+		decode = fmt.Sprintf("if n, e := strconv.ParseFloat(x.Value, 64); e != nil {\nerr = e\n} else {\n%s = %s\n}\n", x, g.convertFrom("float64", ti, "n"))
+	case ti.kind == kindStruct:
+		member = "M"
+		decode = fmt.Sprintf("if e := %s.UnmarshalDynamoDBItem(x.Value); e != nil {\nerr = e\n}\n", x)
+	}
+	if member == "" {
+		body.WriteString(fallback)
+	} else {
+		fmt.Fprintf(&body, "switch x := av.(type) {\ncase *types.AttributeValueMember%s:\n%sdefault:\n%s}\n", member, decode, fallback)
+	}
+
+	aliases := f.aliases()
+	if len(aliases) == 0 {
+		return fmt.Sprintf("if av, ok := item[%q]; ok {\n%s}\n", f.name, body.String())
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "{\nav, ok := item[%q]\n", f.name)
+	for _, alias := range aliases {
+		fmt.Fprintf(&b, "if !ok {\nav, ok = item[%q]\n}\n", alias)
+	}
+	fmt.Fprintf(&b, "if ok {\n%s}\n}\n", body.String())
+	return b.String()
+}
+
+// defaultValue returns the Go source that sets f to the value of its default= option.
+// Values of scalar kinds are parsed now; others are left to fuel.SetDefault.
+func (g *generator) defaultValue(f genField) (string, error) {
+	x := access(f.path)
+	value, _ := f.option("default")
+	ti := g.classify(f.typ, f.imports)
+
+	var lit string
+	var err error
+	switch {
+	case !ti.scalarOr():
+	case ti.importPath == "time" && ti.expr == ti.importName+".Duration":
+		var d time.Duration
+		if d, err = time.ParseDuration(value); err == nil {
+			lit = strconv.FormatInt(int64(d), 10)
+		}
+	case ti.kind == kindString:
+		lit = strconv.Quote(value)
+	case ti.kind == kindBool:
+		var b bool
+		if b, err = strconv.ParseBool(value); err == nil {
+			lit = strconv.FormatBool(b)
+		}
+	case ti.kind == kindInt:
+		var n int64
+		if n, err = strconv.ParseInt(value, 10, ti.bits); err == nil {
+			lit = strconv.FormatInt(n, 10)
+		}
+	case ti.kind == kindUint:
+		var n uint64
+		if n, err = strconv.ParseUint(value, 10, ti.bits); err == nil {
+			lit = strconv.FormatUint(n, 10)
+		}
+	case ti.kind == kindFloat:
+		var n float64
+		if n, err = strconv.ParseFloat(value, ti.bits); err == nil && !math.IsInf(n, 0) && !math.IsNaN(n) {
+			lit = strconv.FormatFloat(n, 'g', -1, 64)
+		}
+	}
+	if err != nil {
+		return "", fmt.Errorf("fuelgen: default for %s: %w", f.name, err)
+	}
+	if lit != "" {
+		return x + " = " + lit + "\n", nil
+	}
+	return fmt.Sprintf("if e := fuel.SetDefault(&%s, %q, %q); e != nil {\nerr = e\n}\n", x, f.name, value), nil
+}
@@ -0,0 +1,92 @@
+// Package fuelgen generates reflection-free implementations of fuel.ItemMarshaler
+// and fuel.ItemUnmarshaler for struct types. It is used by the fuelgen command (see cmd/fuelgen).
+//
+// Generated methods encode and decode items like fuel's default Encoder and Decoder do,
+// honoring the same struct tag options and embedding rules. Options of custom encoders
+// and decoders (naming strategies, hooks, lenient decoding, ...) don't apply to them.
+// Fields of types the generator doesn't handle directly, such as maps or types with
+// their own Marshal and Unmarshal methods, are encoded and decoded with reflection.
+package fuelgen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+)
+
+// Generate returns the source of a file that declares MarshalDynamoDBItem and UnmarshalDynamoDBItem
+// methods for the named struct types of the package in dir.
+// Files previously generated by fuelgen are ignored.
+func Generate(dir string, typeNames ...string) ([]byte, error) {
+	info, err := parsePackage(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(typeNames) == 0 {
+		return nil, fmt.Errorf("fuelgen: no types given")
+	}
+
+	g := &generator{
+		info:     info,
+		generate: make(map[string]bool),
+		imports:  make(map[string]string),
+	}
+	for _, name := range typeNames {
+		g.generate[name] = true
+	}
+	for _, name := range typeNames {
+		fields, err := info.fields(name)
+		if err != nil {
+			return nil, err
+		}
+		g.marshalFunc(name, fields)
+		if err := g.unmarshalFunc(name, fields); err != nil {
+			return nil, fmt.Errorf("%w (type %s)", err, name)
+		}
+	}
+
+	body := g.buf.String()
+	if strings.Contains(body, "strconv.") {
+		g.imports["strconv"] = "strconv"
+	}
+	var std []string
+	for name, path := range g.imports {
+		if name == path[strings.LastIndex(path, "/")+1:] {
+			std = append(std, fmt.Sprintf("%q", path))
+		} else {
+			std = append(std, fmt.Sprintf("%s %q", name, path))
+		}
+	}
+	sort.Strings(std)
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// %s. DO NOT EDIT.\n\npackage %s\n\nimport (\n", generatedPrefix, info.name)
+	for _, imp := range std {
+		fmt.Fprintf(&out, "%s\n", imp)
+	}
+	fmt.Fprintf(&out, "\n%q\n%q\n)\n\n", "github.com/aws/aws-sdk-go-v2/service/dynamodb/types", "github.com/shuymn/fuel")
+	out.WriteString(body)
+	return format.Source(out.Bytes())
+}
+
+// GenerateTest returns the source of a test file for the package in dir
+// that runs fuelgentest.Check for each of the named types.
+func GenerateTest(dir string, typeNames ...string) ([]byte, error) {
+	info, err := parsePackage(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// %s. DO NOT EDIT.\n\npackage %s\n\nimport (\n%q\n\n%q\n)\n\n",
+		generatedPrefix, info.name, "testing", "github.com/shuymn/fuel/fuelgen/fuelgentest")
+	for _, name := range typeNames {
+		if _, _, ok := info.structType(name); !ok {
+			return nil, fmt.Errorf("fuelgen: %s is not a struct type declared in package %s", name, info.name)
+		}
+		fmt.Fprintf(&out, "func TestFuelgen%s(t *testing.T) {\nfuelgentest.Check(t, new(%s))\n}\n\n", strings.ToUpper(name[:1])+name[1:], name)
+	}
+	return format.Source(out.Bytes())
+}
@@ -0,0 +1,90 @@
+package fuelgen
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGenerateUpToDate(t *testing.T) {
+	dir := filepath.Join("internal", "example")
+	typeNames := []string{"Everything", "Nested", "WithPointers"}
+
+	tests := []struct {
+		file     string
+		generate func(string, ...string) ([]byte, error)
+	}{
+		{file: "example_fuel.go", generate: Generate},
+		{file: "example_fuel_test.go", generate: GenerateTest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			want, err := ioutil.ReadFile(filepath.Join(dir, tt.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := tt.generate(dir, typeNames...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(string(want), string(got)); diff != "" {
+				t.Errorf("%s is stale, run go generate (-want, +got):\n%s", tt.file, diff)
+			}
+		})
+	}
+}
+
+func TestGenerateError(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		typ  string
+		want string
+	}{
+		{
+			name: "unknown type",
+			src:  "package p\n\ntype T struct{}\n",
+			typ:  "U",
+			want: "fuelgen: U is not a struct type declared in package p",
+		},
+		{
+			name: "not a struct",
+			src:  "package p\n\ntype T string\n",
+			typ:  "T",
+			want: "fuelgen: T is not a struct type declared in package p",
+		},
+		{
+			name: "embedded type from another package",
+			src:  "package p\n\nimport \"bytes\"\n\ntype T struct {\n\tbytes.Buffer\n}\n",
+			typ:  "T",
+			want: "fuelgen: T: embedded type Buffer is not declared in package p",
+		},
+		{
+			name: "tagged embedded type from another package",
+			src:  "package p\n\nimport \"bytes\"\n\ntype T struct {\n\tbytes.Buffer `dynamodb:\"buf\"`\n}\n",
+			typ:  "T",
+			want: "fuelgen: T: embedded type Buffer is not declared in package p",
+		},
+		{
+			name: "invalid default",
+			src:  "package p\n\ntype T struct {\n\tN int8 `dynamodb:\",default=300\"`\n}\n",
+			typ:  "T",
+			want: "fuelgen: default for N: strconv.ParseInt: parsing \"300\": value out of range (type T)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := ioutil.WriteFile(filepath.Join(dir, "p.go"), []byte(tt.src), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			_, err := Generate(dir, tt.typ)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error missmatch: want %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
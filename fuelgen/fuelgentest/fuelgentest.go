@@ -0,0 +1,222 @@
+// Package fuelgentest checks code generated by fuelgen against fuel's reflection-based
+// encoding. It is meant for tests, such as the ones fuelgen writes with -test.
+package fuelgentest
+
+import (
+	"bytes"
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/shuymn/fuel"
+)
+
+// checkRounds is the number of pseudo-random values Check tries
+const checkRounds = 100
+
+// Check verifies that the generated methods of v, a pointer to a struct, agree with
+// fuel's reflection-based encoding. It compares their results for the zero value and
+// for pseudo-random values of v's type, both when encoding and when decoding.
+func Check(t testing.TB, v interface{}) {
+	t.Helper()
+
+	rt := reflect.TypeOf(v)
+	if rt == nil || rt.Kind() != reflect.Ptr || rt.Elem().Kind() != reflect.Struct {
+		t.Fatalf("fuelgen: check: not a struct pointer: %T", v)
+	}
+	if _, ok := v.(fuel.ItemMarshaler); !ok {
+		t.Fatalf("fuelgen: check: %T doesn't implement fuel.ItemMarshaler", v)
+	}
+	if _, ok := v.(fuel.ItemUnmarshaler); !ok {
+		t.Fatalf("fuelgen: check: %T doesn't implement fuel.ItemUnmarshaler", v)
+	}
+
+	schema, err := fuel.Describe(rt)
+	if err != nil {
+		t.Fatalf("fuelgen: check: %v", err)
+	}
+	aliases := make(map[string][]string)
+	for _, attr := range schema.Attributes {
+		if len(attr.Aliases) > 0 {
+			aliases[attr.Name] = attr.Aliases
+		}
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i <= checkRounds; i++ {
+		in := reflect.New(rt.Elem())
+		if i > 0 {
+			fill(in.Elem(), r, 0)
+		}
+		item, ok := checkMarshal(t, in.Interface())
+		if !ok {
+			continue
+		}
+		checkUnmarshal(t, rt.Elem(), item)
+		checkUnmarshal(t, rt.Elem(), vary(item, aliases, r))
+	}
+}
+
+var itemOpts = cmp.Options{
+	cmpopts.SortSlices(func(a, b string) bool { return a < b }),
+	cmpopts.SortSlices(func(a, b []byte) bool { return bytes.Compare(a, b) < 0 }),
+}
+
+var valueOpts = cmp.Options{
+	cmp.Exporter(func(reflect.Type) bool { return true }),
+}
+
+// checkMarshal compares the generated encoding of v with the reflective one, which it returns
+func checkMarshal(t testing.TB, v interface{}) (map[string]types.AttributeValue, bool) {
+	t.Helper()
+
+	want, wantErr := fuel.MarshalItemReflect(v)
+	got, gotErr := v.(fuel.ItemMarshaler).MarshalDynamoDBItem()
+	if (wantErr == nil) != (gotErr == nil) {
+		t.Errorf("fuelgen: %T: marshal error mismatch: reflection: %v, generated: %v", v, wantErr, gotErr)
+		return nil, false
+	}
+	if wantErr != nil {
+		return nil, false
+	}
+	if diff := cmp.Diff(want, got, itemOpts); diff != "" {
+		t.Errorf("fuelgen: %T: marshal missmatch (-reflection, +generated):\n%s", v, diff)
+	}
+	return want, true
+}
+
+// checkUnmarshal compares the generated decoding of item into a value of type rt with the reflective one
+func checkUnmarshal(t testing.TB, rt reflect.Type, item map[string]types.AttributeValue) {
+	t.Helper()
+
+	want := reflect.New(rt).Interface()
+	got := reflect.New(rt).Interface()
+	wantErr := fuel.UnmarshalItemReflect(item, want)
+	gotErr := got.(fuel.ItemUnmarshaler).UnmarshalDynamoDBItem(item)
+	if (wantErr == nil) != (gotErr == nil) {
+		t.Errorf("fuelgen: %T: unmarshal error mismatch: reflection: %v, generated: %v", got, wantErr, gotErr)
+		return
+	}
+	if diff := cmp.Diff(want, got, valueOpts); diff != "" {
+		t.Errorf("fuelgen: %T: unmarshal missmatch (-reflection, +generated):\n%s", got, diff)
+	}
+}
+
+// vary returns a copy of item with some attributes removed, set to NULL or renamed to one of their aliases,
+// to exercise default values, aliases and decoding of unexpected types
+func vary(item map[string]types.AttributeValue, aliases map[string][]string, r *rand.Rand) map[string]types.AttributeValue {
+	varied := make(map[string]types.AttributeValue, len(item))
+	for name, av := range item {
+		switch r.Intn(5) {
+		case 0:
+			// removed
+		case 1:
+			varied[name] = &types.AttributeValueMemberNULL{Value: true}
+		case 2:
+			if names := aliases[name]; len(names) > 0 {
+				varied[names[r.Intn(len(names))]] = av
+				break
+			}
+			fallthrough
+		default:
+			varied[name] = av
+		}
+	}
+	return varied
+}
+
+// maxFillDepth stops fill from recursing forever into self-referential types
+const maxFillDepth = 6
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// fill sets rv, and the exported fields it contains, to pseudo-random values.
+// Zero, nil and empty values are common on purpose.
+func fill(rv reflect.Value, r *rand.Rand, depth int) {
+	if depth > maxFillDepth || !rv.CanSet() && rv.Kind() != reflect.Struct {
+		return
+	}
+
+	switch rv.Kind() {
+	case reflect.Bool:
+		rv.SetBool(r.Intn(2) == 0)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if r.Intn(4) != 0 {
+			rv.SetInt(r.Int63n(2001) - 1000)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if r.Intn(4) != 0 {
+			rv.SetUint(uint64(r.Int63n(1000)))
+		}
+	case reflect.Float32, reflect.Float64:
+		if r.Intn(4) != 0 {
+			rv.SetFloat(float64(r.Int63n(200001)-100000) / 100)
+		}
+	case reflect.String:
+		if r.Intn(4) != 0 {
+			rv.SetString(randomString(r))
+		}
+	case reflect.Ptr:
+		if r.Intn(3) != 0 {
+			rv.Set(reflect.New(rv.Type().Elem()))
+			fill(rv.Elem(), r, depth+1)
+		}
+	case reflect.Slice:
+		switch r.Intn(4) {
+		case 0:
+			// nil
+		case 1:
+			rv.Set(reflect.MakeSlice(rv.Type(), 0, 0))
+		default:
+			n := 1 + r.Intn(3)
+			rv.Set(reflect.MakeSlice(rv.Type(), n, n))
+			for i := 0; i < n; i++ {
+				fill(rv.Index(i), r, depth+1)
+			}
+		}
+	case reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			fill(rv.Index(i), r, depth+1)
+		}
+	case reflect.Map:
+		if r.Intn(4) == 0 {
+			return
+		}
+		rv.Set(reflect.MakeMap(rv.Type()))
+		for n := r.Intn(4); n > 0; n-- {
+			key := reflect.New(rv.Type().Key()).Elem()
+			elem := reflect.New(rv.Type().Elem()).Elem()
+			fill(key, r, depth+1)
+			fill(elem, r, depth+1)
+			rv.SetMapIndex(key, elem)
+		}
+	case reflect.Interface:
+		if rv.NumMethod() == 0 && r.Intn(2) == 0 {
+			rv.Set(reflect.ValueOf(randomString(r)))
+		}
+	case reflect.Struct:
+		if rv.Type() == timeType {
+			if rv.CanSet() && r.Intn(4) != 0 {
+				rv.Set(reflect.ValueOf(time.Unix(r.Int63n(2000000000), 0).UTC()))
+			}
+			return
+		}
+		for i := 0; i < rv.NumField(); i++ {
+			fill(rv.Field(i), r, depth+1)
+		}
+	}
+}
+
+func randomString(r *rand.Rand) string {
+	const letters = "abcdefghijklmnopqrstuvwxyz"
+	b := make([]byte, 1+r.Intn(8))
+	for i := range b {
+		b[i] = letters[r.Intn(len(letters))]
+	}
+	return string(b)
+}
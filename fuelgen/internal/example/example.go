@@ -0,0 +1,126 @@
+// Package example declares types that exercise the code generated by fuelgen.
+package example
+
+import (
+	"strings"
+	"time"
+)
+
+//go:generate go run ../../../cmd/fuelgen -type Everything,Nested,WithPointers -output example_fuel.go -test
+
+type Status string
+
+type Level int8
+
+// Custom has its own text encoding, so generated code leaves it to fuel
+type Custom string
+
+func (c Custom) MarshalText() ([]byte, error) {
+	return []byte(strings.ToUpper(string(c))), nil
+}
+
+func (c *Custom) UnmarshalText(text []byte) error {
+	*c = Custom(strings.ToLower(string(text)))
+	return nil
+}
+
+type Base struct {
+	ID       string `dynamodb:"id"`
+	Version  int
+	Shadowed string
+	Conflict string
+}
+
+type Audit struct {
+	CreatedAt time.Time `dynamodb:",unixtime"`
+	UpdatedBy *string   `dynamodb:",null"`
+	Shadowed  string
+	Conflict  string
+}
+
+type hidden struct {
+	Secret string
+}
+
+type Extra struct {
+	Note string
+}
+
+type Nested struct {
+	Name  string
+	Count int `dynamodb:",omitempty"`
+}
+
+type Everything struct {
+	Base
+	*Audit
+	hidden
+	*Extra   `dynamodb:"extra"`
+	Shadowed string
+
+	Str            string
+	StrOmit        string `dynamodb:",omitempty"`
+	StrAllow       string `dynamodb:",allowempty"`
+	StrNull        string `dynamodb:",null"`
+	Status         Status
+	Bool           bool
+	BoolOmit       bool `dynamodb:",omitempty"`
+	Int            int
+	Level          Level `dynamodb:",omitempty"`
+	Uint           uint32
+	Float          float64
+	Float32        float32       `dynamodb:",omitempty"`
+	Duration       time.Duration `dynamodb:",default=1m30s"`
+	Retries        int           `dynamodb:",default=3"`
+	Label          string        `dynamodb:",default=none"`
+	Ratio          float64       `dynamodb:",default=0.5"`
+	Enabled        *bool         `dynamodb:",default=true"`
+	PtrStr         *string
+	PtrStrNull     *string `dynamodb:",null,allowempty"`
+	PtrInt         *int    `dynamodb:",omitempty"`
+	Bytes          []byte
+	BytesAllow     []byte     `dynamodb:",allowempty"`
+	BytesNull      []byte     `dynamodb:",null"`
+	BytesOmitAllow []byte     `dynamodb:",omitempty,allowempty"`
+	Time           time.Time  `dynamodb:"time"`
+	TimeOmit       time.Time  `dynamodb:",omitempty"`
+	TimeUnix       time.Time  `dynamodb:",unixtime"`
+	TimePtr        *time.Time `dynamodb:",null"`
+	TimePtrOmit    *time.Time `dynamodb:",omitempty"`
+	TimePtrUnix    *time.Time `dynamodb:",unixtime,null"`
+	List           []string
+	ListOmitElem   []string  `dynamodb:",omitemptyelem"`
+	ListOmit       []int     `dynamodb:",omitempty"`
+	ListNull       []bool    `dynamodb:",null"`
+	Set            []string  `dynamodb:",set"`
+	SetOmitElem    []string  `dynamodb:",set,omitemptyelem"`
+	NumSet         []int     `dynamodb:",set,null"`
+	FloatSet       []float64 `dynamodb:",set,omitemptyelem"`
+	Nested         Nested
+	NestedPtr      *Nested `dynamodb:",null"`
+	NestedOmit     Nested  `dynamodb:",omitempty"`
+	Map            map[string]int
+	MapAllowElem   map[string]string `dynamodb:",allowemptyelem"`
+	MapSet         map[string]bool   `dynamodb:",set"`
+	Custom         Custom
+	CustomSet      []Custom `dynamodb:",set"`
+	Any            interface{}
+	Renamed        string `dynamodb:"renamed,alias=old_name|older_name"`
+	Skipped        string `dynamodb:"-"`
+	Computed       string `dynamodb:"-"`
+	unexported     string
+}
+
+func (e *Everything) SetDefaults() {
+	e.Computed = e.Label + e.unexported
+}
+
+type inner struct {
+	Value int `dynamodb:",default=7"`
+}
+
+type WithPointers struct {
+	*Base
+	*inner
+	Name string `dynamodb:"name"`
+}
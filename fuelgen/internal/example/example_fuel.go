@@ -0,0 +1,935 @@
+// Code generated by fuelgen. DO NOT EDIT.
+
+package example
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/shuymn/fuel"
+)
+
+// MarshalDynamoDBItem implements fuel.ItemMarshaler.
+func (v *Everything) MarshalDynamoDBItem() (map[string]types.AttributeValue, error) {
+	item := make(map[string]types.AttributeValue, 55)
+	if v.Base.ID != "" {
+		item["id"] = &types.AttributeValueMemberS{Value: v.Base.ID}
+	}
+	item["Version"] = &types.AttributeValueMemberN{Value: strconv.FormatInt(int64(v.Base.Version), 10)}
+	if v.Audit != nil {
+		if !v.Audit.CreatedAt.IsZero() {
+			item["CreatedAt"] = &types.AttributeValueMemberN{Value: strconv.FormatInt(v.Audit.CreatedAt.Unix(), 10)}
+		}
+	}
+	if v.Audit != nil {
+		if v.Audit.UpdatedBy != nil {
+			if *v.Audit.UpdatedBy != "" {
+				item["UpdatedBy"] = &types.AttributeValueMemberS{Value: *v.Audit.UpdatedBy}
+			} else {
+				item["UpdatedBy"] = &types.AttributeValueMemberNULL{Value: true}
+			}
+		} else {
+			item["UpdatedBy"] = &types.AttributeValueMemberNULL{Value: true}
+		}
+	}
+	if v.hidden.Secret != "" {
+		item["Secret"] = &types.AttributeValueMemberS{Value: v.hidden.Secret}
+	}
+	if v.Extra != nil {
+		if v.Extra.Note != "" {
+			item["Note"] = &types.AttributeValueMemberS{Value: v.Extra.Note}
+		}
+	}
+	if v.Shadowed != "" {
+		item["Shadowed"] = &types.AttributeValueMemberS{Value: v.Shadowed}
+	}
+	if v.Str != "" {
+		item["Str"] = &types.AttributeValueMemberS{Value: v.Str}
+	}
+	if v.StrOmit != "" {
+		item["StrOmit"] = &types.AttributeValueMemberS{Value: v.StrOmit}
+	}
+	if v.StrAllow != "" {
+		item["StrAllow"] = &types.AttributeValueMemberS{Value: v.StrAllow}
+	} else {
+		item["StrAllow"] = &types.AttributeValueMemberS{Value: ""}
+	}
+	if v.StrNull != "" {
+		item["StrNull"] = &types.AttributeValueMemberS{Value: v.StrNull}
+	} else {
+		item["StrNull"] = &types.AttributeValueMemberNULL{Value: true}
+	}
+	if v.Status != "" {
+		item["Status"] = &types.AttributeValueMemberS{Value: string(v.Status)}
+	}
+	item["Bool"] = &types.AttributeValueMemberBOOL{Value: v.Bool}
+	if v.BoolOmit {
+		item["BoolOmit"] = &types.AttributeValueMemberBOOL{Value: v.BoolOmit}
+	}
+	item["Int"] = &types.AttributeValueMemberN{Value: strconv.FormatInt(int64(v.Int), 10)}
+	if v.Level != 0 {
+		item["Level"] = &types.AttributeValueMemberN{Value: strconv.FormatInt(int64(v.Level), 10)}
+	}
+	item["Uint"] = &types.AttributeValueMemberN{Value: strconv.FormatUint(uint64(v.Uint), 10)}
+	item["Float"] = &types.AttributeValueMemberN{Value: strconv.FormatFloat(v.Float, 'f', -1, 64)}
+	if v.Float32 != 0 {
+		item["Float32"] = &types.AttributeValueMemberN{Value: strconv.FormatFloat(float64(v.Float32), 'f', -1, 64)}
+	}
+	item["Duration"] = &types.AttributeValueMemberN{Value: strconv.FormatInt(int64(v.Duration), 10)}
+	item["Retries"] = &types.AttributeValueMemberN{Value: strconv.FormatInt(int64(v.Retries), 10)}
+	if v.Label != "" {
+		item["Label"] = &types.AttributeValueMemberS{Value: v.Label}
+	}
+	item["Ratio"] = &types.AttributeValueMemberN{Value: strconv.FormatFloat(v.Ratio, 'f', -1, 64)}
+	if v.Enabled != nil {
+		item["Enabled"] = &types.AttributeValueMemberBOOL{Value: *v.Enabled}
+	}
+	if v.PtrStr != nil {
+		if *v.PtrStr != "" {
+			item["PtrStr"] = &types.AttributeValueMemberS{Value: *v.PtrStr}
+		}
+	}
+	if v.PtrStrNull != nil {
+		if *v.PtrStrNull != "" {
+			item["PtrStrNull"] = &types.AttributeValueMemberS{Value: *v.PtrStrNull}
+		} else {
+			item["PtrStrNull"] = &types.AttributeValueMemberS{Value: ""}
+		}
+	} else {
+		item["PtrStrNull"] = &types.AttributeValueMemberNULL{Value: true}
+	}
+	if v.PtrInt != nil {
+		item["PtrInt"] = &types.AttributeValueMemberN{Value: strconv.FormatInt(int64(*v.PtrInt), 10)}
+	}
+	if len(v.Bytes) != 0 {
+		item["Bytes"] = &types.AttributeValueMemberB{Value: v.Bytes}
+	}
+	if len(v.BytesAllow) != 0 {
+		item["BytesAllow"] = &types.AttributeValueMemberB{Value: v.BytesAllow}
+	} else {
+		item["BytesAllow"] = &types.AttributeValueMemberB{Value: []byte{}}
+	}
+	if len(v.BytesNull) != 0 {
+		item["BytesNull"] = &types.AttributeValueMemberB{Value: v.BytesNull}
+	} else if v.BytesNull == nil {
+		item["BytesNull"] = &types.AttributeValueMemberNULL{Value: true}
+	}
+	if len(v.BytesOmitAllow) != 0 {
+		item["BytesOmitAllow"] = &types.AttributeValueMemberB{Value: v.BytesOmitAllow}
+	} else if v.BytesOmitAllow != nil {
+		item["BytesOmitAllow"] = &types.AttributeValueMemberB{Value: []byte{}}
+	}
+	if text, err := v.Time.MarshalText(); err != nil {
+		return nil, err
+	} else {
+		item["time"] = &types.AttributeValueMemberS{Value: string(text)}
+	}
+	if !v.TimeOmit.IsZero() {
+		if text, err := v.TimeOmit.MarshalText(); err != nil {
+			return nil, err
+		} else {
+			item["TimeOmit"] = &types.AttributeValueMemberS{Value: string(text)}
+		}
+	}
+	if !v.TimeUnix.IsZero() {
+		item["TimeUnix"] = &types.AttributeValueMemberN{Value: strconv.FormatInt(v.TimeUnix.Unix(), 10)}
+	}
+	if v.TimePtr != nil {
+		if text, err := v.TimePtr.MarshalText(); err != nil {
+			return nil, err
+		} else {
+			item["TimePtr"] = &types.AttributeValueMemberS{Value: string(text)}
+		}
+	} else {
+		item["TimePtr"] = &types.AttributeValueMemberNULL{Value: true}
+	}
+	if v.TimePtrOmit != nil && !v.TimePtrOmit.IsZero() {
+		if text, err := v.TimePtrOmit.MarshalText(); err != nil {
+			return nil, err
+		} else {
+			item["TimePtrOmit"] = &types.AttributeValueMemberS{Value: string(text)}
+		}
+	}
+	if v.TimePtrUnix != nil {
+		if !v.TimePtrUnix.IsZero() {
+			item["TimePtrUnix"] = &types.AttributeValueMemberN{Value: strconv.FormatInt(v.TimePtrUnix.Unix(), 10)}
+		}
+	} else {
+		item["TimePtrUnix"] = &types.AttributeValueMemberNULL{Value: true}
+	}
+	{
+		avs := make([]types.AttributeValue, 0, len(v.List))
+		for _, elem := range v.List {
+			if elem != "" {
+				avs = append(avs, &types.AttributeValueMemberS{Value: elem})
+			} else {
+				avs = append(avs, &types.AttributeValueMemberS{Value: ""})
+			}
+		}
+		item["List"] = &types.AttributeValueMemberL{Value: avs}
+	}
+	{
+		avs := make([]types.AttributeValue, 0, len(v.ListOmitElem))
+		for _, elem := range v.ListOmitElem {
+			if elem != "" {
+				avs = append(avs, &types.AttributeValueMemberS{Value: elem})
+			}
+		}
+		item["ListOmitElem"] = &types.AttributeValueMemberL{Value: avs}
+	}
+	if v.ListOmit != nil {
+		avs := make([]types.AttributeValue, 0, len(v.ListOmit))
+		for _, elem := range v.ListOmit {
+			avs = append(avs, &types.AttributeValueMemberN{Value: strconv.FormatInt(int64(elem), 10)})
+		}
+		if len(avs) != 0 {
+			item["ListOmit"] = &types.AttributeValueMemberL{Value: avs}
+		}
+	}
+	if v.ListNull == nil {
+		item["ListNull"] = &types.AttributeValueMemberNULL{Value: true}
+	} else {
+		avs := make([]types.AttributeValue, 0, len(v.ListNull))
+		for _, elem := range v.ListNull {
+			avs = append(avs, &types.AttributeValueMemberBOOL{Value: elem})
+		}
+		item["ListNull"] = &types.AttributeValueMemberL{Value: avs}
+	}
+	if len(v.Set) != 0 {
+		ss := make([]string, 0, len(v.Set))
+		for _, elem := range v.Set {
+			ss = append(ss, elem)
+		}
+		if len(ss) != 0 {
+			item["Set"] = &types.AttributeValueMemberSS{Value: ss}
+		}
+	}
+	if len(v.SetOmitElem) != 0 {
+		ss := make([]string, 0, len(v.SetOmitElem))
+		for _, elem := range v.SetOmitElem {
+			if elem != "" {
+				ss = append(ss, elem)
+			}
+		}
+		if len(ss) != 0 {
+			item["SetOmitElem"] = &types.AttributeValueMemberSS{Value: ss}
+		}
+	}
+	if v.NumSet == nil {
+		item["NumSet"] = &types.AttributeValueMemberNULL{Value: true}
+	} else if len(v.NumSet) != 0 {
+		ss := make([]string, 0, len(v.NumSet))
+		for _, elem := range v.NumSet {
+			ss = append(ss, strconv.FormatInt(int64(elem), 10))
+		}
+		if len(ss) != 0 {
+			item["NumSet"] = &types.AttributeValueMemberNS{Value: ss}
+		}
+	}
+	if len(v.FloatSet) != 0 {
+		ss := make([]string, 0, len(v.FloatSet))
+		for _, elem := range v.FloatSet {
+			if elem != 0 {
+				ss = append(ss, strconv.FormatFloat(elem, 'f', -1, 64))
+			}
+		}
+		if len(ss) != 0 {
+			item["FloatSet"] = &types.AttributeValueMemberNS{Value: ss}
+		}
+	}
+	if sub, err := v.Nested.MarshalDynamoDBItem(); err != nil {
+		return nil, err
+	} else {
+		item["Nested"] = &types.AttributeValueMemberM{Value: sub}
+	}
+	if v.NestedPtr != nil {
+		if sub, err := v.NestedPtr.MarshalDynamoDBItem(); err != nil {
+			return nil, err
+		} else {
+			item["NestedPtr"] = &types.AttributeValueMemberM{Value: sub}
+		}
+	} else {
+		item["NestedPtr"] = &types.AttributeValueMemberNULL{Value: true}
+	}
+	if av, err := fuel.MarshalField(&v.NestedOmit, "omitempty"); err != nil {
+		return nil, err
+	} else if av != nil {
+		item["NestedOmit"] = av
+	}
+	if av, err := fuel.MarshalField(&v.Map, ""); err != nil {
+		return nil, err
+	} else if av != nil {
+		item["Map"] = av
+	}
+	if av, err := fuel.MarshalField(&v.MapAllowElem, "allowemptyelem"); err != nil {
+		return nil, err
+	} else if av != nil {
+		item["MapAllowElem"] = av
+	}
+	if av, err := fuel.MarshalField(&v.MapSet, "set"); err != nil {
+		return nil, err
+	} else if av != nil {
+		item["MapSet"] = av
+	}
+	if av, err := fuel.MarshalField(&v.Custom, ""); err != nil {
+		return nil, err
+	} else if av != nil {
+		item["Custom"] = av
+	}
+	if av, err := fuel.MarshalField(&v.CustomSet, "set"); err != nil {
+		return nil, err
+	} else if av != nil {
+		item["CustomSet"] = av
+	}
+	if av, err := fuel.MarshalField(&v.Any, ""); err != nil {
+		return nil, err
+	} else if av != nil {
+		item["Any"] = av
+	}
+	if v.Renamed != "" {
+		item["renamed"] = &types.AttributeValueMemberS{Value: v.Renamed}
+	}
+	return item, nil
+}
+
+// UnmarshalDynamoDBItem implements fuel.ItemUnmarshaler.
+func (v *Everything) UnmarshalDynamoDBItem(item map[string]types.AttributeValue) error {
+	*v = Everything{}
+	var err error
+	if av, ok := item["id"]; ok {
+		switch x := av.(type) {
+		case *types.AttributeValueMemberS:
+			v.Base.ID = x.Value
+		default:
+			if e := fuel.Unmarshal(av, &v.Base.ID); e != nil {
+				err = e
+			}
+		}
+	}
+	if av, ok := item["Version"]; ok {
+		switch x := av.(type) {
+		case *types.AttributeValueMemberN:
+			if n, e := strconv.ParseInt(x.Value, 10, 64); e != nil {
+				err = e
+			} else {
+				v.Base.Version = int(n)
+			}
+		default:
+			if e := fuel.Unmarshal(av, &v.Base.Version); e != nil {
+				err = e
+			}
+		}
+	}
+	if av, ok := item["CreatedAt"]; ok {
+		if v.Audit == nil {
+			v.Audit = new(Audit)
+		}
+		if e := fuel.Unmarshal(av, &v.Audit.CreatedAt); e != nil {
+			err = e
+		}
+	}
+	if av, ok := item["UpdatedBy"]; ok {
+		if v.Audit == nil {
+			v.Audit = new(Audit)
+		}
+		if e := fuel.Unmarshal(av, &v.Audit.UpdatedBy); e != nil {
+			err = e
+		}
+	}
+	if av, ok := item["Secret"]; ok {
+		switch x := av.(type) {
+		case *types.AttributeValueMemberS:
+			v.hidden.Secret = x.Value
+		default:
+			if e := fuel.Unmarshal(av, &v.hidden.Secret); e != nil {
+				err = e
+			}
+		}
+	}
+	if av, ok := item["Note"]; ok {
+		if v.Extra == nil {
+			v.Extra = new(Extra)
+		}
+		switch x := av.(type) {
+		case *types.AttributeValueMemberS:
+			v.Extra.Note = x.Value
+		default:
+			if e := fuel.Unmarshal(av, &v.Extra.Note); e != nil {
+				err = e
+			}
+		}
+	}
+	if av, ok := item["Shadowed"]; ok {
+		switch x := av.(type) {
+		case *types.AttributeValueMemberS:
+			v.Shadowed = x.Value
+		default:
+			if e := fuel.Unmarshal(av, &v.Shadowed); e != nil {
+				err = e
+			}
+		}
+	}
+	if av, ok := item["Str"]; ok {
+		switch x := av.(type) {
+		case *types.AttributeValueMemberS:
+			v.Str = x.Value
+		default:
+			if e := fuel.Unmarshal(av, &v.Str); e != nil {
+				err = e
+			}
+		}
+	}
+	if av, ok := item["StrOmit"]; ok {
+		switch x := av.(type) {
+		case *types.AttributeValueMemberS:
+			v.StrOmit = x.Value
+		default:
+			if e := fuel.Unmarshal(av, &v.StrOmit); e != nil {
+				err = e
+			}
+		}
+	}
+	if av, ok := item["StrAllow"]; ok {
+		switch x := av.(type) {
+		case *types.AttributeValueMemberS:
+			v.StrAllow = x.Value
+		default:
+			if e := fuel.Unmarshal(av, &v.StrAllow); e != nil {
+				err = e
+			}
+		}
+	}
+	if av, ok := item["StrNull"]; ok {
+		switch x := av.(type) {
+		case *types.AttributeValueMemberS:
+			v.StrNull = x.Value
+		default:
+			if e := fuel.Unmarshal(av, &v.StrNull); e != nil {
+				err = e
+			}
+		}
+	}
+	if av, ok := item["Status"]; ok {
+		switch x := av.(type) {
+		case *types.AttributeValueMemberS:
+			v.Status = Status(x.Value)
+		default:
+			if e := fuel.Unmarshal(av, &v.Status); e != nil {
+				err = e
+			}
+		}
+	}
+	if av, ok := item["Bool"]; ok {
+		switch x := av.(type) {
+		case *types.AttributeValueMemberBOOL:
+			v.Bool = x.Value
+		default:
+			if e := fuel.Unmarshal(av, &v.Bool); e != nil {
+				err = e
+			}
+		}
+	}
+	if av, ok := item["BoolOmit"]; ok {
+		switch x := av.(type) {
+		case *types.AttributeValueMemberBOOL:
+			v.BoolOmit = x.Value
+		default:
+			if e := fuel.Unmarshal(av, &v.BoolOmit); e != nil {
+				err = e
+			}
+		}
+	}
+	if av, ok := item["Int"]; ok {
+		switch x := av.(type) {
+		case *types.AttributeValueMemberN:
+			if n, e := strconv.ParseInt(x.Value, 10, 64); e != nil {
+				err = e
+			} else {
+				v.Int = int(n)
+			}
+		default:
+			if e := fuel.Unmarshal(av, &v.Int); e != nil {
+				err = e
+			}
+		}
+	}
+	if av, ok := item["Level"]; ok {
+		switch x := av.(type) {
+		case *types.AttributeValueMemberN:
+			if n, e := strconv.ParseInt(x.Value, 10, 64); e != nil {
+				err = e
+			} else {
+				v.Level = Level(n)
+			}
+		default:
+			if e := fuel.Unmarshal(av, &v.Level); e != nil {
+				err = e
+			}
+		}
+	}
+	if av, ok := item["Uint"]; ok {
+		switch x := av.(type) {
+		case *types.AttributeValueMemberN:
+			if n, e := strconv.ParseUint(x.Value, 10, 64); e != nil {
+				err = e
+			} else {
+				v.Uint = uint32(n)
+			}
+		default:
+			if e := fuel.Unmarshal(av, &v.Uint); e != nil {
+				err = e
+			}
+		}
+	}
+	if av, ok := item["Float"]; ok {
+		switch x := av.(type) {
+		case *types.AttributeValueMemberN:
+			if n, e := strconv.ParseFloat(x.Value, 64); e != nil {
+				err = e
+			} else {
+				v.Float = n
+			}
+		default:
+			if e := fuel.Unmarshal(av, &v.Float); e != nil {
+				err = e
+			}
+		}
+	}
+	if av, ok := item["Float32"]; ok {
+		switch x := av.(type) {
+		case *types.AttributeValueMemberN:
+			if n, e := strconv.ParseFloat(x.Value, 64); e != nil {
+				err = e
+			} else {
+				v.Float32 = float32(n)
+			}
+		default:
+			if e := fuel.Unmarshal(av, &v.Float32); e != nil {
+				err = e
+			}
+		}
+	}
+	if av, ok := item["Duration"]; ok {
+		switch x := av.(type) {
+		case *types.AttributeValueMemberN:
+			if n, e := strconv.ParseInt(x.Value, 10, 64); e != nil {
+				err = e
+			} else {
+				v.Duration = time.Duration(n)
+			}
+		default:
+			if e := fuel.Unmarshal(av, &v.Duration); e != nil {
+				err = e
+			}
+		}
+	}
+	if av, ok := item["Retries"]; ok {
+		switch x := av.(type) {
+		case *types.AttributeValueMemberN:
+			if n, e := strconv.ParseInt(x.Value, 10, 64); e != nil {
+				err = e
+			} else {
+				v.Retries = int(n)
+			}
+		default:
+			if e := fuel.Unmarshal(av, &v.Retries); e != nil {
+				err = e
+			}
+		}
+	}
+	if av, ok := item["Label"]; ok {
+		switch x := av.(type) {
+		case *types.AttributeValueMemberS:
+			v.Label = x.Value
+		default:
+			if e := fuel.Unmarshal(av, &v.Label); e != nil {
+				err = e
+			}
+		}
+	}
+	if av, ok := item["Ratio"]; ok {
+		switch x := av.(type) {
+		case *types.AttributeValueMemberN:
+			if n, e := strconv.ParseFloat(x.Value, 64); e != nil {
+				err = e
+			} else {
+				v.Ratio = n
+			}
+		default:
+			if e := fuel.Unmarshal(av, &v.Ratio); e != nil {
+				err = e
+			}
+		}
+	}
+	if av, ok := item["Enabled"]; ok {
+		if e := fuel.Unmarshal(av, &v.Enabled); e != nil {
+			err = e
+		}
+	}
+	if av, ok := item["PtrStr"]; ok {
+		if e := fuel.Unmarshal(av, &v.PtrStr); e != nil {
+			err = e
+		}
+	}
+	if av, ok := item["PtrStrNull"]; ok {
+		if e := fuel.Unmarshal(av, &v.PtrStrNull); e != nil {
+			err = e
+		}
+	}
+	if av, ok := item["PtrInt"]; ok {
+		if e := fuel.Unmarshal(av, &v.PtrInt); e != nil {
+			err = e
+		}
+	}
+	if av, ok := item["Bytes"]; ok {
+		if e := fuel.Unmarshal(av, &v.Bytes); e != nil {
+			err = e
+		}
+	}
+	if av, ok := item["BytesAllow"]; ok {
+		if e := fuel.Unmarshal(av, &v.BytesAllow); e != nil {
+			err = e
+		}
+	}
+	if av, ok := item["BytesNull"]; ok {
+		if e := fuel.Unmarshal(av, &v.BytesNull); e != nil {
+			err = e
+		}
+	}
+	if av, ok := item["BytesOmitAllow"]; ok {
+		if e := fuel.Unmarshal(av, &v.BytesOmitAllow); e != nil {
+			err = e
+		}
+	}
+	if av, ok := item["time"]; ok {
+		if e := fuel.Unmarshal(av, &v.Time); e != nil {
+			err = e
+		}
+	}
+	if av, ok := item["TimeOmit"]; ok {
+		if e := fuel.Unmarshal(av, &v.TimeOmit); e != nil {
+			err = e
+		}
+	}
+	if av, ok := item["TimeUnix"]; ok {
+		if e := fuel.Unmarshal(av, &v.TimeUnix); e != nil {
+			err = e
+		}
+	}
+	if av, ok := item["TimePtr"]; ok {
+		if e := fuel.Unmarshal(av, &v.TimePtr); e != nil {
+			err = e
+		}
+	}
+	if av, ok := item["TimePtrOmit"]; ok {
+		if e := fuel.Unmarshal(av, &v.TimePtrOmit); e != nil {
+			err = e
+		}
+	}
+	if av, ok := item["TimePtrUnix"]; ok {
+		if e := fuel.Unmarshal(av, &v.TimePtrUnix); e != nil {
+			err = e
+		}
+	}
+	if av, ok := item["List"]; ok {
+		if e := fuel.Unmarshal(av, &v.List); e != nil {
+			err = e
+		}
+	}
+	if av, ok := item["ListOmitElem"]; ok {
+		if e := fuel.Unmarshal(av, &v.ListOmitElem); e != nil {
+			err = e
+		}
+	}
+	if av, ok := item["ListOmit"]; ok {
+		if e := fuel.Unmarshal(av, &v.ListOmit); e != nil {
+			err = e
+		}
+	}
+	if av, ok := item["ListNull"]; ok {
+		if e := fuel.Unmarshal(av, &v.ListNull); e != nil {
+			err = e
+		}
+	}
+	if av, ok := item["Set"]; ok {
+		if e := fuel.Unmarshal(av, &v.Set); e != nil {
+			err = e
+		}
+	}
+	if av, ok := item["SetOmitElem"]; ok {
+		if e := fuel.Unmarshal(av, &v.SetOmitElem); e != nil {
+			err = e
+		}
+	}
+	if av, ok := item["NumSet"]; ok {
+		if e := fuel.Unmarshal(av, &v.NumSet); e != nil {
+			err = e
+		}
+	}
+	if av, ok := item["FloatSet"]; ok {
+		if e := fuel.Unmarshal(av, &v.FloatSet); e != nil {
+			err = e
+		}
+	}
+	if av, ok := item["Nested"]; ok {
+		switch x := av.(type) {
+		case *types.AttributeValueMemberM:
+			if e := v.Nested.UnmarshalDynamoDBItem(x.Value); e != nil {
+				err = e
+			}
+		default:
+			if e := fuel.Unmarshal(av, &v.Nested); e != nil {
+				err = e
+			}
+		}
+	}
+	if av, ok := item["NestedPtr"]; ok {
+		if e := fuel.Unmarshal(av, &v.NestedPtr); e != nil {
+			err = e
+		}
+	}
+	if av, ok := item["NestedOmit"]; ok {
+		switch x := av.(type) {
+		case *types.AttributeValueMemberM:
+			if e := v.NestedOmit.UnmarshalDynamoDBItem(x.Value); e != nil {
+				err = e
+			}
+		default:
+			if e := fuel.Unmarshal(av, &v.NestedOmit); e != nil {
+				err = e
+			}
+		}
+	}
+	if av, ok := item["Map"]; ok {
+		if e := fuel.Unmarshal(av, &v.Map); e != nil {
+			err = e
+		}
+	}
+	if av, ok := item["MapAllowElem"]; ok {
+		if e := fuel.Unmarshal(av, &v.MapAllowElem); e != nil {
+			err = e
+		}
+	}
+	if av, ok := item["MapSet"]; ok {
+		if e := fuel.Unmarshal(av, &v.MapSet); e != nil {
+			err = e
+		}
+	}
+	if av, ok := item["Custom"]; ok {
+		if e := fuel.Unmarshal(av, &v.Custom); e != nil {
+			err = e
+		}
+	}
+	if av, ok := item["CustomSet"]; ok {
+		if e := fuel.Unmarshal(av, &v.CustomSet); e != nil {
+			err = e
+		}
+	}
+	if av, ok := item["Any"]; ok {
+		if e := fuel.Unmarshal(av, &v.Any); e != nil {
+			err = e
+		}
+	}
+	{
+		av, ok := item["renamed"]
+		if !ok {
+			av, ok = item["old_name"]
+		}
+		if !ok {
+			av, ok = item["older_name"]
+		}
+		if ok {
+			switch x := av.(type) {
+			case *types.AttributeValueMemberS:
+				v.Renamed = x.Value
+			default:
+				if e := fuel.Unmarshal(av, &v.Renamed); e != nil {
+					err = e
+				}
+			}
+		}
+	}
+	if _, ok := item["Duration"]; !ok {
+		v.Duration = 90000000000
+	}
+	if _, ok := item["Retries"]; !ok {
+		v.Retries = 3
+	}
+	if _, ok := item["Label"]; !ok {
+		v.Label = "none"
+	}
+	if _, ok := item["Ratio"]; !ok {
+		v.Ratio = 0.5
+	}
+	if _, ok := item["Enabled"]; !ok {
+		if e := fuel.SetDefault(&v.Enabled, "Enabled", "true"); e != nil {
+			err = e
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if defaulter, ok := interface{}(v).(fuel.Defaulter); ok {
+		defaulter.SetDefaults()
+	}
+	return nil
+}
+
+// MarshalDynamoDBItem implements fuel.ItemMarshaler.
+func (v *Nested) MarshalDynamoDBItem() (map[string]types.AttributeValue, error) {
+	item := make(map[string]types.AttributeValue, 2)
+	if v.Name != "" {
+		item["Name"] = &types.AttributeValueMemberS{Value: v.Name}
+	}
+	if v.Count != 0 {
+		item["Count"] = &types.AttributeValueMemberN{Value: strconv.FormatInt(int64(v.Count), 10)}
+	}
+	return item, nil
+}
+
+// UnmarshalDynamoDBItem implements fuel.ItemUnmarshaler.
+func (v *Nested) UnmarshalDynamoDBItem(item map[string]types.AttributeValue) error {
+	*v = Nested{}
+	var err error
+	if av, ok := item["Name"]; ok {
+		switch x := av.(type) {
+		case *types.AttributeValueMemberS:
+			v.Name = x.Value
+		default:
+			if e := fuel.Unmarshal(av, &v.Name); e != nil {
+				err = e
+			}
+		}
+	}
+	if av, ok := item["Count"]; ok {
+		switch x := av.(type) {
+		case *types.AttributeValueMemberN:
+			if n, e := strconv.ParseInt(x.Value, 10, 64); e != nil {
+				err = e
+			} else {
+				v.Count = int(n)
+			}
+		default:
+			if e := fuel.Unmarshal(av, &v.Count); e != nil {
+				err = e
+			}
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if defaulter, ok := interface{}(v).(fuel.Defaulter); ok {
+		defaulter.SetDefaults()
+	}
+	return nil
+}
+
+// MarshalDynamoDBItem implements fuel.ItemMarshaler.
+func (v *WithPointers) MarshalDynamoDBItem() (map[string]types.AttributeValue, error) {
+	item := make(map[string]types.AttributeValue, 6)
+	if v.Base != nil {
+		if v.Base.ID != "" {
+			item["id"] = &types.AttributeValueMemberS{Value: v.Base.ID}
+		}
+	}
+	if v.Base != nil {
+		item["Version"] = &types.AttributeValueMemberN{Value: strconv.FormatInt(int64(v.Base.Version), 10)}
+	}
+	if v.Base != nil {
+		if v.Base.Shadowed != "" {
+			item["Shadowed"] = &types.AttributeValueMemberS{Value: v.Base.Shadowed}
+		}
+	}
+	if v.Base != nil {
+		if v.Base.Conflict != "" {
+			item["Conflict"] = &types.AttributeValueMemberS{Value: v.Base.Conflict}
+		}
+	}
+	if v.inner != nil {
+		item["Value"] = &types.AttributeValueMemberN{Value: strconv.FormatInt(int64(v.inner.Value), 10)}
+	}
+	if v.Name != "" {
+		item["name"] = &types.AttributeValueMemberS{Value: v.Name}
+	}
+	return item, nil
+}
+
+// UnmarshalDynamoDBItem implements fuel.ItemUnmarshaler.
+func (v *WithPointers) UnmarshalDynamoDBItem(item map[string]types.AttributeValue) error {
+	*v = WithPointers{}
+	var err error
+	if av, ok := item["id"]; ok {
+		if v.Base == nil {
+			v.Base = new(Base)
+		}
+		switch x := av.(type) {
+		case *types.AttributeValueMemberS:
+			v.Base.ID = x.Value
+		default:
+			if e := fuel.Unmarshal(av, &v.Base.ID); e != nil {
+				err = e
+			}
+		}
+	}
+	if av, ok := item["Version"]; ok {
+		if v.Base == nil {
+			v.Base = new(Base)
+		}
+		switch x := av.(type) {
+		case *types.AttributeValueMemberN:
+			if n, e := strconv.ParseInt(x.Value, 10, 64); e != nil {
+				err = e
+			} else {
+				v.Base.Version = int(n)
+			}
+		default:
+			if e := fuel.Unmarshal(av, &v.Base.Version); e != nil {
+				err = e
+			}
+		}
+	}
+	if av, ok := item["Shadowed"]; ok {
+		if v.Base == nil {
+			v.Base = new(Base)
+		}
+		switch x := av.(type) {
+		case *types.AttributeValueMemberS:
+			v.Base.Shadowed = x.Value
+		default:
+			if e := fuel.Unmarshal(av, &v.Base.Shadowed); e != nil {
+				err = e
+			}
+		}
+	}
+	if av, ok := item["Conflict"]; ok {
+		if v.Base == nil {
+			v.Base = new(Base)
+		}
+		switch x := av.(type) {
+		case *types.AttributeValueMemberS:
+			v.Base.Conflict = x.Value
+		default:
+			if e := fuel.Unmarshal(av, &v.Base.Conflict); e != nil {
+				err = e
+			}
+		}
+	}
+	if av, ok := item["name"]; ok {
+		switch x := av.(type) {
+		case *types.AttributeValueMemberS:
+			v.Name = x.Value
+		default:
+			if e := fuel.Unmarshal(av, &v.Name); e != nil {
+				err = e
+			}
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if defaulter, ok := interface{}(v).(fuel.Defaulter); ok {
+		defaulter.SetDefaults()
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+// Code generated by fuelgen. DO NOT EDIT.
+
+package example
+
+import (
+	"testing"
+
+	"github.com/shuymn/fuel/fuelgen/fuelgentest"
+)
+
+func TestFuelgenEverything(t *testing.T) {
+	fuelgentest.Check(t, new(Everything))
+}
+
+func TestFuelgenNested(t *testing.T) {
+	fuelgentest.Check(t, new(Nested))
+}
+
+func TestFuelgenWithPointers(t *testing.T) {
+	fuelgentest.Check(t, new(WithPointers))
+}
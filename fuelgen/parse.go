@@ -0,0 +1,347 @@
+package fuelgen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// generatedPrefix starts the first comment of files written by fuelgen
+const generatedPrefix = "Code generated by fuelgen"
+
+// pkgInfo holds the declarations of a parsed package
+type pkgInfo struct {
+	name  string
+	types map[string]*typeDecl
+	// method names by receiver type name
+	methods map[string]map[string]bool
+}
+
+type typeDecl struct {
+	spec *ast.TypeSpec
+	// imports of the declaring file, by local name
+	imports map[string]string
+}
+
+// parsePackage parses the non-test Go files in dir, skipping files generated by fuelgen
+func parsePackage(dir string) (*pkgInfo, error) {
+	fset := token.NewFileSet()
+	notTest := func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}
+	pkgs, err := parser.ParseDir(fset, dir, notTest, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("fuelgen: expected one package in %s, found %d", dir, len(pkgs))
+	}
+
+	info := &pkgInfo{
+		types:   make(map[string]*typeDecl),
+		methods: make(map[string]map[string]bool),
+	}
+	for name, pkg := range pkgs {
+		info.name = name
+		for _, file := range pkg.Files {
+			if isGenerated(file) {
+				continue
+			}
+			info.addFile(file)
+		}
+	}
+	return info, nil
+}
+
+func isGenerated(file *ast.File) bool {
+	return len(file.Comments) > 0 && strings.HasPrefix(file.Comments[0].Text(), generatedPrefix)
+}
+
+func (info *pkgInfo) addFile(file *ast.File) {
+	imports := make(map[string]string)
+	for _, spec := range file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		name := path[strings.LastIndex(path, "/")+1:]
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		imports[name] = path
+	}
+
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.GenDecl:
+			if decl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range decl.Specs {
+				ts := spec.(*ast.TypeSpec)
+				info.types[ts.Name.Name] = &typeDecl{spec: ts, imports: imports}
+			}
+		case *ast.FuncDecl:
+			if decl.Recv == nil || len(decl.Recv.List) == 0 {
+				continue
+			}
+			recv := decl.Recv.List[0].Type
+			if star, ok := recv.(*ast.StarExpr); ok {
+				recv = star.X
+			}
+			ident, ok := recv.(*ast.Ident)
+			if !ok {
+				continue
+			}
+			if info.methods[ident.Name] == nil {
+				info.methods[ident.Name] = make(map[string]bool)
+			}
+			info.methods[ident.Name][decl.Name.Name] = true
+		}
+	}
+}
+
+// structType returns the struct type declared as name, following type definitions of other struct types.
+// It also returns the imports of the file that declares the struct type.
+func (info *pkgInfo) structType(name string) (*ast.StructType, map[string]string, bool) {
+	seen := make(map[string]bool)
+	for !seen[name] {
+		seen[name] = true
+		decl, ok := info.types[name]
+		if !ok {
+			return nil, nil, false
+		}
+		switch t := decl.spec.Type.(type) {
+		case *ast.StructType:
+			return t, decl.imports, true
+		case *ast.Ident:
+			name = t.Name
+		default:
+			return nil, nil, false
+		}
+	}
+	return nil, nil, false
+}
+
+// step is one struct field on the way to a promoted field
+type step struct {
+	name string
+	// the field is an embedded pointer, of type *typeName
+	ptr      bool
+	typeName string
+	exported bool
+}
+
+// genField is a struct field, possibly promoted from an embedded struct, that holds an attribute.
+// It mirrors the field type of package fuel.
+type genField struct {
+	name    string
+	tagged  bool
+	index   []int
+	path    []step
+	typ     ast.Expr
+	imports map[string]string
+	// tag options after the name
+	options []string
+}
+
+// option returns the value of a key=value tag option
+func (f genField) option(key string) (string, bool) {
+	for _, opt := range f.options {
+		if strings.HasPrefix(opt, key+"=") {
+			return opt[len(key)+1:], true
+		}
+	}
+	return "", false
+}
+
+func (f genField) has(flag string) bool {
+	for _, opt := range f.options {
+		if opt == flag {
+			return true
+		}
+	}
+	return false
+}
+
+func (f genField) aliases() []string {
+	if alias, ok := f.option("alias"); ok && alias != "" {
+		return strings.Split(alias, "|")
+	}
+	return nil
+}
+
+// embeddedName returns the name and type name of an embedded field of type expr.
+// The type name is empty if the type isn't declared in this package.
+func embeddedName(expr ast.Expr) (name string, typeName string, ptr bool) {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+		ptr = true
+	}
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name, t.Name, ptr
+	case *ast.SelectorExpr:
+		return t.Sel.Name, "", ptr
+	}
+	return "", "", ptr
+}
+
+// fields resolves the fields of the struct type name like fuel does at run time:
+// breadth-first over embedded structs, with encoding/json's rules for conflicts.
+func (info *pkgInfo) fields(name string) ([]genField, error) {
+	if _, _, ok := info.structType(name); !ok {
+		return nil, fmt.Errorf("fuelgen: %s is not a struct type declared in package %s", name, info.name)
+	}
+
+	type node struct {
+		typeName string
+		index    []int
+		path     []step
+	}
+
+	var fields []genField
+	var current []node
+	next := []node{{typeName: name}}
+	var count, nextCount map[string]int
+	visited := make(map[string]bool)
+
+	for len(next) > 0 {
+		current, next = next, nil
+		count, nextCount = nextCount, make(map[string]int)
+
+		for _, n := range current {
+			if visited[n.typeName] {
+				continue
+			}
+			visited[n.typeName] = true
+			st, imports, _ := info.structType(n.typeName)
+
+			i := -1
+			for _, af := range st.Fields.List {
+				var tag reflect.StructTag
+				if af.Tag != nil {
+					s, _ := strconv.Unquote(af.Tag.Value)
+					tag = reflect.StructTag(s)
+				}
+				tags := strings.Split(tag.Get("dynamodb"), ",")
+				tagged := tags[0] != ""
+
+				names := af.Names
+				embedded := len(names) == 0
+				if embedded {
+					fieldName, _, _ := embeddedName(af.Type)
+					names = []*ast.Ident{ast.NewIdent(fieldName)}
+				}
+				for _, ident := range names {
+					i++
+					var typeName string
+					var ptr, isStruct bool
+					if embedded {
+						_, typeName, ptr = embeddedName(af.Type)
+						if typeName != "" {
+							_, _, isStruct = info.structType(typeName)
+						} else {
+							// can't tell whether its fields are promoted
+							return nil, fmt.Errorf("fuelgen: %s: embedded type %s is not declared in package %s", name, ident.Name, info.name)
+						}
+						// unexported embedded non-structs are ignored
+						if !ident.IsExported() && !isStruct {
+							continue
+						}
+					} else if !ident.IsExported() {
+						// unexported fields are ignored
+						continue
+					}
+
+					attr := tags[0]
+					if attr == "-" {
+						continue
+					}
+					if attr == "" {
+						attr = ident.Name
+					}
+
+					index := append(append([]int(nil), n.index...), i)
+					path := append(append([]step(nil), n.path...), step{
+						name:     ident.Name,
+						ptr:      ptr,
+						typeName: typeName,
+						exported: ident.IsExported(),
+					})
+
+					if !embedded || !isStruct {
+						fields = append(fields, genField{
+							name:    attr,
+							tagged:  tagged,
+							index:   index,
+							path:    path,
+							typ:     af.Type,
+							imports: imports,
+							options: tags[1:],
+						})
+						if count[n.typeName] > 1 {
+							// embedded more than once at this depth: add a duplicate
+							// so the conflict resolution below drops it
+							fields = append(fields, fields[len(fields)-1])
+						}
+						continue
+					}
+
+					// embedded struct, search its fields next
+					nextCount[typeName]++
+					if nextCount[typeName] == 1 {
+						next = append(next, node{typeName: typeName, index: index, path: path})
+					}
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(fields, func(i, j int) bool {
+		x := fields
+		if x[i].name != x[j].name {
+			return x[i].name < x[j].name
+		}
+		if len(x[i].index) != len(x[j].index) {
+			return len(x[i].index) < len(x[j].index)
+		}
+		if x[i].tagged != x[j].tagged {
+			return x[i].tagged
+		}
+		return indexLess(x[i].index, x[j].index)
+	})
+
+	var list []genField
+	for i := 0; i < len(fields); {
+		// all fields with the same name
+		j := i + 1
+		for j < len(fields) && fields[j].name == fields[i].name {
+			j++
+		}
+		if j-i == 1 || len(fields[i].index) != len(fields[i+1].index) || fields[i].tagged != fields[i+1].tagged {
+			list = append(list, fields[i])
+		}
+		i = j
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return indexLess(list[i].index, list[j].index)
+	})
+	return list, nil
+}
+
+func indexLess(a, b []int) bool {
+	for k, x := range a {
+		if k >= len(b) {
+			return false
+		}
+		if x != b[k] {
+			return x < b[k]
+		}
+	}
+	return len(a) < len(b)
+}
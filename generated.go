@@ -0,0 +1,67 @@
+package fuel
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// The functions in this file support code generated by fuelgen (see cmd/fuelgen).
+// They are exported so that generated code can fall back to reflection
+// for types the generator doesn't encode directly.
+
+// MarshalField converts the struct field pointed to by ptr like MarshalItem would,
+// given the options of its dynamodb struct tag (such as "omitempty,set").
+// It returns a nil AttributeValue if the field should be left out of the item.
+func MarshalField(ptr interface{}, options string) (types.AttributeValue, error) {
+	rv := reflect.ValueOf(ptr)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return nil, fmt.Errorf("dynamodb: marshal field: not a pointer: %T", ptr)
+	}
+	fv := rv.Elem()
+	flags := parseFlags(strings.Split(options, ","))
+	if flags&flagOmitEmpty != 0 && isZero(fv) {
+		return nil, nil
+	}
+	return defaultEncoder.marshal(fv.Interface(), flags)
+}
+
+// SetDefault sets the struct field pointed to by ptr to the value of a default= tag option.
+// name is the attribute name of the field, used in errors.
+func SetDefault(ptr interface{}, name, value string) error {
+	rv := reflect.ValueOf(ptr)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("dynamodb: set default: not a pointer: %T", ptr)
+	}
+	if err := setDefault(rv.Elem(), value); err != nil {
+		return fmt.Errorf("dynamodb: default for %s: %w", name, err)
+	}
+	return nil
+}
+
+// MarshalItemReflect is like MarshalItem, but always encodes the fields of the struct v
+// using reflection, even if v implements ItemMarshaler.
+// It doesn't stamp the schema version.
+func MarshalItemReflect(v interface{}) (map[string]types.AttributeValue, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("dynamodb: marshal item: not a struct: %T", v)
+	}
+	return defaultEncoder.marshalStruct(rv)
+}
+
+// UnmarshalItemReflect is like UnmarshalItem, but always decodes into the fields of
+// the struct pointed to by out using reflection, even if out implements ItemUnmarshaler.
+// It doesn't apply migrations.
+func UnmarshalItemReflect(item map[string]types.AttributeValue, out interface{}) error {
+	rv := reflect.ValueOf(out)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("dynamodb: unmarshal item: not a struct pointer: %T", out)
+	}
+	return defaultDecoder.unmarshalStruct(item, rv, nil)
+}
@@ -0,0 +1,87 @@
+package fuel
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMarshalField(t *testing.T) {
+	var (
+		empty   string
+		tags    = []string{"a"}
+		nilTags []string
+	)
+	tests := []struct {
+		name    string
+		ptr     interface{}
+		options string
+		want    types.AttributeValue
+	}{
+		{name: "omitempty", ptr: &empty, options: "omitempty", want: nil},
+		{name: "allowempty", ptr: &empty, options: "allowempty,default=x", want: &types.AttributeValueMemberS{Value: ""}},
+		{name: "set", ptr: &tags, options: "set", want: &types.AttributeValueMemberSS{Value: []string{"a"}}},
+		{name: "list", ptr: &tags, options: "", want: &types.AttributeValueMemberL{Value: []types.AttributeValue{&types.AttributeValueMemberS{Value: "a"}}}},
+		{name: "nil set with null", ptr: &nilTags, options: "set,null", want: &types.AttributeValueMemberNULL{Value: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MarshalField(tt.ptr, tt.options)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("missmatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSetDefault(t *testing.T) {
+	var n int
+	if err := SetDefault(&n, "n", "42"); err != nil {
+		t.Fatal(err)
+	}
+	if n != 42 {
+		t.Errorf("missmatch: want 42, got %d", n)
+	}
+
+	err := SetDefault(&n, "n", "many")
+	want := `dynamodb: default for n: strconv.ParseInt: parsing "many": invalid syntax`
+	if err == nil || err.Error() != want {
+		t.Errorf("error missmatch: want %q, got %v", want, err)
+	}
+}
+
+type itemMarshalerStruct struct {
+	Name string
+}
+
+func (*itemMarshalerStruct) MarshalDynamoDBItem() (map[string]types.AttributeValue, error) {
+	return map[string]types.AttributeValue{"custom": &types.AttributeValueMemberBOOL{Value: true}}, nil
+}
+
+func (v *itemMarshalerStruct) UnmarshalDynamoDBItem(map[string]types.AttributeValue) error {
+	v.Name = "custom"
+	return nil
+}
+
+func TestItemReflect(t *testing.T) {
+	item, err := MarshalItemReflect(&itemMarshalerStruct{Name: "fuel"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]types.AttributeValue{"Name": &types.AttributeValueMemberS{Value: "fuel"}}
+	if diff := cmp.Diff(want, item); diff != "" {
+		t.Errorf("marshal missmatch (-want, +got):\n%s", diff)
+	}
+
+	var out itemMarshalerStruct
+	if err := UnmarshalItemReflect(item, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Name != "fuel" {
+		t.Errorf("unmarshal missmatch: want fuel, got %s", out.Name)
+	}
+}
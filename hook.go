@@ -0,0 +1,67 @@
+package fuel
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// EncodeHook converts a value of the type it is registered for into an AttributeValue.
+// Returning a nil AttributeValue omits the value.
+type EncodeHook func(v interface{}) (types.AttributeValue, error)
+
+// DecodeHook converts av into a value of the target type.
+// It reports false if it doesn't handle this conversion, in which case
+// the next hook or the built-in decoding is used.
+// The returned value must be assignable to target, or have the same kind and be convertible to it.
+type DecodeHook func(av types.AttributeValue, target reflect.Type) (interface{}, bool, error)
+
+// UseEncodeHook registers hook to encode values of type t, taking precedence over
+// Marshaler, encoding.TextMarshaler and the built-in encoding.
+// This is useful for third-party types that can't implement Marshaler.
+func UseEncodeHook(t reflect.Type, hook EncodeHook) EncoderOption {
+	return encoderOptionFunc(func(e *Encoder) {
+		if e.hooks == nil {
+			e.hooks = make(map[reflect.Type]EncodeHook)
+		}
+		e.hooks[t] = hook
+	})
+}
+
+// UseDecodeHook adds hook to the hooks a Decoder consults, in order,
+// before Unmarshaler, encoding.TextUnmarshaler and the built-in decoding.
+func UseDecodeHook(hook DecodeHook) DecoderOption {
+	return decoderOptionFunc(func(d *Decoder) {
+		d.hooks = append(d.hooks, hook)
+	})
+}
+
+// decodeHook runs the decode hooks, reporting whether one of them handled av
+func (d *Decoder) decodeHook(av types.AttributeValue, rv reflect.Value) (bool, error) {
+	for _, hook := range d.hooks {
+		v, ok, err := hook(av, rv.Type())
+		if err != nil {
+			return true, err
+		}
+		if !ok {
+			continue
+		}
+
+		if v == nil {
+			rv.Set(reflect.Zero(rv.Type()))
+			return true, nil
+		}
+		val := reflect.ValueOf(v)
+		switch {
+		case val.Type().AssignableTo(rv.Type()):
+		case val.Kind() == rv.Kind() && val.Type().ConvertibleTo(rv.Type()):
+			val = val.Convert(rv.Type())
+		default:
+			return true, fmt.Errorf("dynamodb: decode hook returned %s, not assignable to %s", val.Type(), rv.Type())
+		}
+		rv.Set(val)
+		return true, nil
+	}
+	return false, nil
+}
@@ -0,0 +1,103 @@
+package fuel
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/go-cmp/cmp"
+)
+
+// hookAddr stands in for a third-party type with unexported fields
+type hookAddr struct {
+	ip string
+}
+
+var hookAddrType = reflect.TypeOf(hookAddr{})
+
+func encodeHookAddr(v interface{}) (types.AttributeValue, error) {
+	return &types.AttributeValueMemberS{Value: v.(hookAddr).ip}, nil
+}
+
+func decodeHookAddr(av types.AttributeValue, target reflect.Type) (interface{}, bool, error) {
+	if target != hookAddrType {
+		return nil, false, nil
+	}
+	avS, ok := av.(*types.AttributeValueMemberS)
+	if !ok {
+		return nil, true, errors.New("not a string")
+	}
+	return hookAddr{ip: avS.Value}, true, nil
+}
+
+type hookItem struct {
+	Addr  hookAddr
+	Ptr   *hookAddr
+	List  []hookAddr
+	Other string
+}
+
+func TestHooksRoundTrip(t *testing.T) {
+	in := hookItem{
+		Addr:  hookAddr{ip: "10.0.0.1"},
+		Ptr:   &hookAddr{ip: "10.0.0.2"},
+		List:  []hookAddr{{ip: "10.0.0.3"}},
+		Other: "ok",
+	}
+	want := map[string]types.AttributeValue{
+		"Addr": &types.AttributeValueMemberS{Value: "10.0.0.1"},
+		"Ptr":  &types.AttributeValueMemberS{Value: "10.0.0.2"},
+		"List": &types.AttributeValueMemberL{Value: []types.AttributeValue{
+			&types.AttributeValueMemberS{Value: "10.0.0.3"},
+		}},
+		"Other": &types.AttributeValueMemberS{Value: "ok"},
+	}
+
+	enc := NewEncoder(UseEncodeHook(hookAddrType, encodeHookAddr))
+	item, err := enc.MarshalItem(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want, item); diff != "" {
+		t.Errorf("encode missmatch (-want, +got):\n%s", diff)
+	}
+
+	dec := NewDecoder(UseDecodeHook(decodeHookAddr))
+	var out hookItem
+	if err := dec.UnmarshalItem(item, &out); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(in, out, cmp.AllowUnexported(hookAddr{})); diff != "" {
+		t.Errorf("decode missmatch (-want, +got):\n%s", diff)
+	}
+}
+
+func TestDecodeHookErrors(t *testing.T) {
+	dec := NewDecoder(UseDecodeHook(decodeHookAddr))
+	var out hookItem
+	err := dec.UnmarshalItem(map[string]types.AttributeValue{
+		"Addr": &types.AttributeValueMemberN{Value: "1"},
+	}, &out)
+	if err == nil {
+		t.Error("expected error from hook, got nil")
+	}
+
+	bad := NewDecoder(UseDecodeHook(func(av types.AttributeValue, target reflect.Type) (interface{}, bool, error) {
+		return 123, target.Kind() == reflect.String, nil
+	}))
+	if err := bad.Unmarshal(&types.AttributeValueMemberS{Value: "x"}, new(string)); err == nil {
+		t.Error("expected error for unassignable hook result, got nil")
+	}
+
+	converted := NewDecoder(UseDecodeHook(func(av types.AttributeValue, target reflect.Type) (interface{}, bool, error) {
+		return "converted", target == reflect.TypeOf(customString("")), nil
+	}))
+	var cs customString
+	if err := converted.Unmarshal(&types.AttributeValueMemberS{Value: "x"}, &cs); err != nil {
+		t.Fatal(err)
+	}
+	if cs != "converted" {
+		t.Errorf("want converted, got %q", cs)
+	}
+}
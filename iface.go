@@ -0,0 +1,297 @@
+package fuel
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// NumberMode controls how numbers are decoded into interface{} values.
+type NumberMode int
+
+const (
+	// NumberAsFloat64 decodes numbers as float64. This is the default.
+	NumberAsFloat64 NumberMode = iota
+	// NumberAsInt64 decodes integral numbers as int64 and the rest as float64.
+	NumberAsInt64
+	// NumberAsNumber decodes numbers as Number, keeping their exact value.
+	NumberAsNumber
+	// NumberAsJSON decodes numbers as json.Number, keeping their exact value.
+	NumberAsJSON
+)
+
+// SetMode controls how sets are decoded into interface{} values.
+type SetMode int
+
+const (
+	// SetAsSlice decodes SS as []string, NS as a slice of numbers and BS as [][]byte.
+	// This is the default, and loses the distinction between sets and lists.
+	SetAsSlice SetMode = iota
+	// SetAsSetType decodes sets as StringSet, NumberSet and BinarySet.
+	SetAsSetType
+	// SetAsMap decodes SS as map[string]struct{}, NS as map[Number]struct{}
+	// and BS as map[string]struct{} with the binary values as keys.
+	SetAsMap
+)
+
+// BinaryMode controls how binary data is decoded into interface{} values.
+type BinaryMode int
+
+const (
+	// BinaryAsBytes decodes binary data as []byte. This is the default.
+	BinaryAsBytes BinaryMode = iota
+	// BinaryAsBase64 decodes binary data as standard base64 encoded strings.
+	// Binary sets decoded as BinarySet are unaffected.
+	BinaryAsBase64
+)
+
+// NumbersAs sets how a Decoder decodes numbers into interface{} values.
+func NumbersAs(mode NumberMode) DecoderOption {
+	return decoderOptionFunc(func(d *Decoder) {
+		d.numberMode = mode
+	})
+}
+
+// SetsAs sets how a Decoder decodes sets into interface{} values.
+func SetsAs(mode SetMode) DecoderOption {
+	return decoderOptionFunc(func(d *Decoder) {
+		d.setMode = mode
+	})
+}
+
+// BinaryAs sets how a Decoder decodes binary data into interface{} values.
+func BinaryAs(mode BinaryMode) DecoderOption {
+	return decoderOptionFunc(func(d *Decoder) {
+		d.binaryMode = mode
+	})
+}
+
+// Number is a DynamoDB number in its exact string form.
+type Number string
+
+// String returns the number as a string
+func (n Number) String() string {
+	return string(n)
+}
+
+// Int64 returns the number as an int64
+func (n Number) Int64() (int64, error) {
+	return strconv.ParseInt(string(n), 10, 64)
+}
+
+// Uint64 returns the number as a uint64
+func (n Number) Uint64() (uint64, error) {
+	return strconv.ParseUint(string(n), 10, 64)
+}
+
+// Float64 returns the number as a float64
+func (n Number) Float64() (float64, error) {
+	return strconv.ParseFloat(string(n), 64)
+}
+
+// MarshalDynamoDB implements the Marshaler interface
+func (n Number) MarshalDynamoDB() (types.AttributeValue, error) {
+	if n == "" {
+		return nil, nil
+	}
+	return &types.AttributeValueMemberN{Value: string(n)}, nil
+}
+
+// UnmarshalDynamoDB implements the Unmarshaler interface
+func (n *Number) UnmarshalDynamoDB(av types.AttributeValue) error {
+	switch x := av.(type) {
+	case *types.AttributeValueMemberN:
+		*n = Number(x.Value)
+		return nil
+	case *types.AttributeValueMemberNULL:
+		*n = ""
+		return nil
+	}
+	return fmt.Errorf("dynamodb: cannot unmarshal %s data into Number", avTypeName(av))
+}
+
+// StringSet is a set of strings, encoded as SS
+type StringSet []string
+
+// MarshalDynamoDB implements the Marshaler interface
+func (ss StringSet) MarshalDynamoDB() (types.AttributeValue, error) {
+	if len(ss) == 0 {
+		return nil, nil
+	}
+	return &types.AttributeValueMemberSS{Value: append([]string(nil), ss...)}, nil
+}
+
+// UnmarshalDynamoDB implements the Unmarshaler interface
+func (ss *StringSet) UnmarshalDynamoDB(av types.AttributeValue) error {
+	switch x := av.(type) {
+	case *types.AttributeValueMemberSS:
+		*ss = append(StringSet(nil), x.Value...)
+		return nil
+	case *types.AttributeValueMemberNULL:
+		*ss = nil
+		return nil
+	}
+	return fmt.Errorf("dynamodb: cannot unmarshal %s data into StringSet", avTypeName(av))
+}
+
+// NumberSet is a set of numbers, encoded as NS
+type NumberSet []Number
+
+// MarshalDynamoDB implements the Marshaler interface
+func (ns NumberSet) MarshalDynamoDB() (types.AttributeValue, error) {
+	if len(ns) == 0 {
+		return nil, nil
+	}
+	strs := make([]string, 0, len(ns))
+	for _, n := range ns {
+		strs = append(strs, string(n))
+	}
+	return &types.AttributeValueMemberNS{Value: strs}, nil
+}
+
+// UnmarshalDynamoDB implements the Unmarshaler interface
+func (ns *NumberSet) UnmarshalDynamoDB(av types.AttributeValue) error {
+	switch x := av.(type) {
+	case *types.AttributeValueMemberNS:
+		set := make(NumberSet, 0, len(x.Value))
+		for _, n := range x.Value {
+			set = append(set, Number(n))
+		}
+		*ns = set
+		return nil
+	case *types.AttributeValueMemberNULL:
+		*ns = nil
+		return nil
+	}
+	return fmt.Errorf("dynamodb: cannot unmarshal %s data into NumberSet", avTypeName(av))
+}
+
+// BinarySet is a set of binary values, encoded as BS
+type BinarySet [][]byte
+
+// MarshalDynamoDB implements the Marshaler interface
+func (bs BinarySet) MarshalDynamoDB() (types.AttributeValue, error) {
+	if len(bs) == 0 {
+		return nil, nil
+	}
+	return &types.AttributeValueMemberBS{Value: append([][]byte(nil), bs...)}, nil
+}
+
+// UnmarshalDynamoDB implements the Unmarshaler interface
+func (bs *BinarySet) UnmarshalDynamoDB(av types.AttributeValue) error {
+	switch x := av.(type) {
+	case *types.AttributeValueMemberBS:
+		*bs = append(BinarySet(nil), x.Value...)
+		return nil
+	case *types.AttributeValueMemberNULL:
+		*bs = nil
+		return nil
+	}
+	return fmt.Errorf("dynamodb: cannot unmarshal %s data into BinarySet", avTypeName(av))
+}
+
+func (d *Decoder) number(n string) (interface{}, error) {
+	switch d.numberMode {
+	case NumberAsInt64:
+		if i, err := strconv.ParseInt(n, 10, 64); err == nil {
+			return i, nil
+		}
+	case NumberAsNumber:
+		return Number(n), nil
+	case NumberAsJSON:
+		return json.Number(n), nil
+	}
+	return strconv.ParseFloat(n, 64)
+}
+
+func (d *Decoder) numberSet(ns []string) (interface{}, error) {
+	switch d.setMode {
+	case SetAsSetType:
+		set := make(NumberSet, 0, len(ns))
+		for _, n := range ns {
+			set = append(set, Number(n))
+		}
+		return set, nil
+	case SetAsMap:
+		set := make(map[Number]struct{}, len(ns))
+		for _, n := range ns {
+			set[Number(n)] = struct{}{}
+		}
+		return set, nil
+	}
+
+	switch d.numberMode {
+	case NumberAsInt64:
+		ints := make([]int64, 0, len(ns))
+		for _, n := range ns {
+			i, err := strconv.ParseInt(n, 10, 64)
+			if err != nil {
+				// not all integral, fall back to floats
+				ints = nil
+				break
+			}
+			ints = append(ints, i)
+		}
+		if ints != nil {
+			return ints, nil
+		}
+	case NumberAsNumber:
+		set := make([]Number, 0, len(ns))
+		for _, n := range ns {
+			set = append(set, Number(n))
+		}
+		return set, nil
+	case NumberAsJSON:
+		set := make([]json.Number, 0, len(ns))
+		for _, n := range ns {
+			set = append(set, json.Number(n))
+		}
+		return set, nil
+	}
+
+	set := make([]float64, 0, len(ns))
+	for _, n := range ns {
+		f, err := strconv.ParseFloat(n, 64)
+		if err != nil {
+			return nil, err
+		}
+		set = append(set, f)
+	}
+	return set, nil
+}
+
+func (d *Decoder) binary(b []byte) interface{} {
+	if d.binaryMode == BinaryAsBase64 {
+		return base64.StdEncoding.EncodeToString(b)
+	}
+	return b
+}
+
+func (d *Decoder) binarySet(bs [][]byte) interface{} {
+	switch d.setMode {
+	case SetAsSetType:
+		return append(BinarySet(nil), bs...)
+	case SetAsMap:
+		set := make(map[string]struct{}, len(bs))
+		for _, b := range bs {
+			if d.binaryMode == BinaryAsBase64 {
+				set[base64.StdEncoding.EncodeToString(b)] = struct{}{}
+			} else {
+				set[string(b)] = struct{}{}
+			}
+		}
+		return set
+	}
+
+	if d.binaryMode == BinaryAsBase64 {
+		strs := make([]string, 0, len(bs))
+		for _, b := range bs {
+			strs = append(strs, base64.StdEncoding.EncodeToString(b))
+		}
+		return strs
+	}
+	return bs
+}
@@ -0,0 +1,144 @@
+package fuel
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/go-cmp/cmp"
+)
+
+var ifaceTestItem = map[string]types.AttributeValue{
+	"Int":   &types.AttributeValueMemberN{Value: "9007199254740993"},
+	"Float": &types.AttributeValueMemberN{Value: "1.5"},
+	"SS":    &types.AttributeValueMemberSS{Value: []string{"A", "B"}},
+	"NS":    &types.AttributeValueMemberNS{Value: []string{"1", "2"}},
+	"B":     &types.AttributeValueMemberB{Value: []byte("hi")},
+	"BS":    &types.AttributeValueMemberBS{Value: [][]byte{[]byte("hi")}},
+}
+
+var ifaceDecodeTests = []struct {
+	name string
+	opts []DecoderOption
+	want map[string]interface{}
+}{
+	{
+		name: "defaults",
+		want: map[string]interface{}{
+			"Int":   9007199254740992.0,
+			"Float": 1.5,
+			"SS":    []string{"A", "B"},
+			"NS":    []float64{1, 2},
+			"B":     []byte("hi"),
+			"BS":    [][]byte{[]byte("hi")},
+		},
+	},
+	{
+		name: "int64 numbers",
+		opts: []DecoderOption{NumbersAs(NumberAsInt64)},
+		want: map[string]interface{}{
+			"Int":   int64(9007199254740993),
+			"Float": 1.5,
+			"SS":    []string{"A", "B"},
+			"NS":    []int64{1, 2},
+			"B":     []byte("hi"),
+			"BS":    [][]byte{[]byte("hi")},
+		},
+	},
+	{
+		name: "Number",
+		opts: []DecoderOption{NumbersAs(NumberAsNumber)},
+		want: map[string]interface{}{
+			"Int":   Number("9007199254740993"),
+			"Float": Number("1.5"),
+			"SS":    []string{"A", "B"},
+			"NS":    []Number{"1", "2"},
+			"B":     []byte("hi"),
+			"BS":    [][]byte{[]byte("hi")},
+		},
+	},
+	{
+		name: "json.Number",
+		opts: []DecoderOption{NumbersAs(NumberAsJSON)},
+		want: map[string]interface{}{
+			"Int":   json.Number("9007199254740993"),
+			"Float": json.Number("1.5"),
+			"SS":    []string{"A", "B"},
+			"NS":    []json.Number{"1", "2"},
+			"B":     []byte("hi"),
+			"BS":    [][]byte{[]byte("hi")},
+		},
+	},
+	{
+		name: "set types",
+		opts: []DecoderOption{SetsAs(SetAsSetType)},
+		want: map[string]interface{}{
+			"Int":   9007199254740992.0,
+			"Float": 1.5,
+			"SS":    StringSet{"A", "B"},
+			"NS":    NumberSet{"1", "2"},
+			"B":     []byte("hi"),
+			"BS":    BinarySet{[]byte("hi")},
+		},
+	},
+	{
+		name: "set maps with base64",
+		opts: []DecoderOption{SetsAs(SetAsMap), BinaryAs(BinaryAsBase64)},
+		want: map[string]interface{}{
+			"Int":   9007199254740992.0,
+			"Float": 1.5,
+			"SS":    map[string]struct{}{"A": {}, "B": {}},
+			"NS":    map[Number]struct{}{"1": {}, "2": {}},
+			"B":     "aGk=",
+			"BS":    map[string]struct{}{"aGk=": {}},
+		},
+	},
+}
+
+func TestUnmarshalInterfaceOptions(t *testing.T) {
+	for _, tc := range ifaceDecodeTests {
+		var got map[string]interface{}
+		if err := NewDecoder(tc.opts...).UnmarshalItem(ifaceTestItem, &got); err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+			continue
+		}
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Errorf("%s: missmatch (-want, +got):\n%s", tc.name, diff)
+		}
+	}
+}
+
+func TestInterfaceRoundTrip(t *testing.T) {
+	dec := NewDecoder(NumbersAs(NumberAsJSON), SetsAs(SetAsSetType))
+	var got map[string]interface{}
+	if err := dec.UnmarshalItem(ifaceTestItem, &got); err != nil {
+		t.Fatal(err)
+	}
+	item, err := MarshalItem(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(ifaceTestItem, item); diff != "" {
+		t.Errorf("missmatch (-want, +got):\n%s", diff)
+	}
+}
+
+func TestNumber(t *testing.T) {
+	var n Number
+	if err := Unmarshal(&types.AttributeValueMemberN{Value: "12"}, &n); err != nil {
+		t.Fatal(err)
+	}
+	if i, err := n.Int64(); err != nil || i != 12 {
+		t.Errorf("Int64: want 12, got %d (err: %v)", i, err)
+	}
+
+	var result struct {
+		N json.Number
+	}
+	if err := UnmarshalItem(map[string]types.AttributeValue{"N": &types.AttributeValueMemberN{Value: "1.25"}}, &result); err != nil {
+		t.Fatal(err)
+	}
+	if result.N != "1.25" {
+		t.Errorf("json.Number: want 1.25, got %s", result.N)
+	}
+}
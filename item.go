@@ -0,0 +1,183 @@
+package fuel
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// ErrNoAttribute is returned when a path does not exist in an item
+var ErrNoAttribute = errors.New("dynamodb: attribute not found")
+
+// Item is a read-only view over a DynamoDB item that allows picking out
+// single values by document path (such as "orders[2].lines[0].sku")
+// without unmarshaling the whole item.
+type Item map[string]types.AttributeValue
+
+// Get returns the attribute value at path. If there is none, the error is ErrNoAttribute;
+// other errors report a malformed path.
+func (item Item) Get(path string) (types.AttributeValue, error) {
+	return item.lookup(path)
+}
+
+// Has reports whether an attribute exists at path. It is false for malformed paths too;
+// use Get to tell them apart.
+func (item Item) Has(path string) bool {
+	_, err := item.Get(path)
+	return err == nil
+}
+
+// Decode unmarshals the attribute value at path into the value pointed to by out,
+// using the default Decoder
+func (item Item) Decode(path string, out interface{}) error {
+	return defaultDecoder.DecodeItemPath(item, path, out)
+}
+
+// DecodeItemPath unmarshals the attribute value at path in item into the value pointed to by out
+func (d *Decoder) DecodeItemPath(item Item, path string, out interface{}) error {
+	elems, err := parsePath(path)
+	if err != nil {
+		return err
+	}
+	av, err := item.lookupElems(path, elems)
+	if err != nil {
+		return err
+	}
+	rv := reflect.ValueOf(out)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("dynamodb: item decode: not a pointer: %T", out)
+	}
+	var at *decodePath
+	for _, elem := range elems {
+		if elem.isIndex() {
+			at = d.index(at, elem.index)
+		} else {
+			at = d.field(at, elem.name)
+		}
+	}
+	if err := d.unmarshalReflect(av, rv.Elem(), at); err != nil {
+		return fmt.Errorf("dynamodb: item decode %s: %w", path, err)
+	}
+	return nil
+}
+
+// String returns the string at path
+func (item Item) String(path string) (string, error) {
+	var s string
+	err := item.Decode(path, &s)
+	return s, err
+}
+
+// Int64 returns the number at path as an int64
+func (item Item) Int64(path string) (int64, error) {
+	var n int64
+	err := item.Decode(path, &n)
+	return n, err
+}
+
+// Float64 returns the number at path as a float64
+func (item Item) Float64(path string) (float64, error) {
+	var f float64
+	err := item.Decode(path, &f)
+	return f, err
+}
+
+// Bool returns the boolean at path
+func (item Item) Bool(path string) (bool, error) {
+	var b bool
+	err := item.Decode(path, &b)
+	return b, err
+}
+
+// Time returns the time at path, encoded either as an RFC 3339 string or as unix time
+func (item Item) Time(path string) (time.Time, error) {
+	var t time.Time
+	err := item.Decode(path, &t)
+	return t, err
+}
+
+// Range calls fn for each member of the map or list at path, in key or index order.
+// fn receives the full path of each member, which can be passed back to Item's accessors:
+// map keys containing '.', '[', ']' or '\' are escaped with a backslash.
+// An empty path ranges over the top-level attributes.
+// Iteration stops if fn returns false.
+func (item Item) Range(path string, fn func(path string, av types.AttributeValue) bool) error {
+	var av types.AttributeValue = &types.AttributeValueMemberM{Value: item}
+	var elems []pathElem
+	if path != "" {
+		var err error
+		if elems, err = parsePath(path); err != nil {
+			return err
+		}
+		if av, err = item.lookupElems(path, elems); err != nil {
+			return err
+		}
+	}
+	// sub returns the path of a member
+	sub := func(elem pathElem) string {
+		return formatPath(append(elems[:len(elems):len(elems)], elem))
+	}
+
+	switch x := av.(type) {
+	case *types.AttributeValueMemberM:
+		keys := make([]string, 0, len(x.Value))
+		for k := range x.Value {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if !fn(sub(pathElem{name: k}), x.Value[k]) {
+				return nil
+			}
+		}
+		return nil
+	case *types.AttributeValueMemberL:
+		for i, v := range x.Value {
+			if !fn(sub(pathElem{index: i}), v) {
+				return nil
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("dynamodb: item range %s: cannot range over %s", path, avTypeName(av))
+}
+
+func (item Item) lookup(path string) (types.AttributeValue, error) {
+	elems, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	return item.lookupElems(path, elems)
+}
+
+func (item Item) lookupElems(path string, elems []pathElem) (types.AttributeValue, error) {
+	var av types.AttributeValue = &types.AttributeValueMemberM{Value: item}
+	for _, elem := range elems {
+		switch x := av.(type) {
+		case *types.AttributeValueMemberM:
+			if elem.isIndex() {
+				return nil, fmt.Errorf("dynamodb: item path %s: cannot index map", path)
+			}
+			v, ok := x.Value[elem.name]
+			if !ok {
+				return nil, fmt.Errorf("%w: %s", ErrNoAttribute, path)
+			}
+			av = v
+		case *types.AttributeValueMemberL:
+			if !elem.isIndex() {
+				return nil, fmt.Errorf("dynamodb: item path %s: cannot get attribute %s of list", path, elem.name)
+			}
+			if elem.index >= len(x.Value) {
+				return nil, fmt.Errorf("%w: %s", ErrNoAttribute, path)
+			}
+			av = x.Value[elem.index]
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrNoAttribute, path)
+		}
+	}
+	return av, nil
+}
@@ -0,0 +1,161 @@
+package fuel
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/go-cmp/cmp"
+)
+
+var testItem = Item{
+	"ID":      &types.AttributeValueMemberS{Value: "user#1"},
+	"Age":     &types.AttributeValueMemberN{Value: "31"},
+	"Created": &types.AttributeValueMemberN{Value: "1546300800"},
+	"orders": &types.AttributeValueMemberL{Value: []types.AttributeValue{
+		&types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+			"lines": &types.AttributeValueMemberL{Value: []types.AttributeValue{
+				&types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+					"sku": &types.AttributeValueMemberS{Value: "A-1"},
+					"qty": &types.AttributeValueMemberN{Value: "2"},
+				}},
+			}},
+		}},
+	}},
+}
+
+func TestItemAccessors(t *testing.T) {
+	if s, err := testItem.String("orders[0].lines[0].sku"); err != nil || s != "A-1" {
+		t.Errorf("String: want A-1, got %q (err: %v)", s, err)
+	}
+	if n, err := testItem.Int64("orders[0].lines[0].qty"); err != nil || n != 2 {
+		t.Errorf("Int64: want 2, got %d (err: %v)", n, err)
+	}
+	if f, err := testItem.Float64("Age"); err != nil || f != 31 {
+		t.Errorf("Float64: want 31, got %v (err: %v)", f, err)
+	}
+	want := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	if tm, err := testItem.Time("Created"); err != nil || !tm.Equal(want) {
+		t.Errorf("Time: want %v, got %v (err: %v)", want, tm, err)
+	}
+
+	var line struct {
+		SKU string `dynamodb:"sku"`
+		Qty int    `dynamodb:"qty"`
+	}
+	if err := testItem.Decode("orders[0].lines[0]", &line); err != nil {
+		t.Fatal(err)
+	}
+	if line.SKU != "A-1" || line.Qty != 2 {
+		t.Errorf("Decode: bad result %+v", line)
+	}
+
+	if _, err := testItem.String("Age"); err == nil {
+		t.Error("String on number: expected error")
+	}
+}
+
+func TestItemMissing(t *testing.T) {
+	for _, path := range []string{"Nope", "orders[1]", "orders[0].lines[0].price", "ID.sub", "ID[0]"} {
+		if testItem.Has(path) {
+			t.Errorf("%s: Has reported true", path)
+		}
+		if _, err := testItem.String(path); !errors.Is(err, ErrNoAttribute) {
+			t.Errorf("%s: want ErrNoAttribute, got %v", path, err)
+		}
+	}
+	if !testItem.Has("orders[0].lines") {
+		t.Error("Has(orders[0].lines) reported false")
+	}
+	if _, err := testItem.Get("orders[x]"); err == nil || errors.Is(err, ErrNoAttribute) {
+		t.Errorf("malformed path: want a path error, got %v", err)
+	}
+}
+
+func TestItemRange(t *testing.T) {
+	var paths []string
+	err := testItem.Range("", func(path string, _ types.AttributeValue) bool {
+		paths = append(paths, path)
+		return true
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"Age", "Created", "ID", "orders"}, paths); diff != "" {
+		t.Errorf("top-level missmatch (-want, +got):\n%s", diff)
+	}
+
+	paths = nil
+	err = testItem.Range("orders[0].lines[0]", func(path string, _ types.AttributeValue) bool {
+		paths = append(paths, path)
+		return true
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"orders[0].lines[0].qty", "orders[0].lines[0].sku"}, paths); diff != "" {
+		t.Errorf("nested missmatch (-want, +got):\n%s", diff)
+	}
+
+	paths = nil
+	err = testItem.Range("orders", func(path string, _ types.AttributeValue) bool {
+		paths = append(paths, path)
+		return false
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"orders[0]"}, paths); diff != "" {
+		t.Errorf("list missmatch (-want, +got):\n%s", diff)
+	}
+
+	dotted := Item{"a.b": &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+		"x[0]": &types.AttributeValueMemberS{Value: "v"},
+	}}}
+	paths = nil
+	err = dotted.Range(`a\.b`, func(path string, _ types.AttributeValue) bool {
+		paths = append(paths, path)
+		return true
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{`a\.b.x\[0\]`}, paths); diff != "" {
+		t.Errorf("escaped missmatch (-want, +got):\n%s", diff)
+	}
+	if s, err := dotted.String(paths[0]); err != nil || s != "v" {
+		t.Errorf("String(%s): want v, got %q (err: %v)", paths[0], s, err)
+	}
+
+	if err := testItem.Range("ID", func(string, types.AttributeValue) bool { return true }); err == nil {
+		t.Error("Range over string: expected error")
+	}
+}
+
+func TestDecodeItemPath(t *testing.T) {
+	item := Item{"orders": &types.AttributeValueMemberL{Value: []types.AttributeValue{
+		&types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+			"qty": &types.AttributeValueMemberS{Value: "3"},
+		}},
+	}}}
+
+	var qty int
+	if err := item.Decode("orders[0].qty", &qty); err == nil {
+		t.Error("default decoder: expected error, got nil")
+	}
+
+	var paths []string
+	dec := NewDecoder(Lenient(func(c Coercion) {
+		paths = append(paths, c.Path)
+	}))
+	if err := dec.DecodeItemPath(item, "orders[0].qty", &qty); err != nil {
+		t.Fatal(err)
+	}
+	if qty != 3 {
+		t.Errorf("bad value: want 3, got %d", qty)
+	}
+	if diff := cmp.Diff([]string{"orders[0].qty"}, paths); diff != "" {
+		t.Errorf("coercion path missmatch (-want, +got):\n%s", diff)
+	}
+}
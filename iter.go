@@ -0,0 +1,113 @@
+package fuel
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// Iter iterates over the results of a request such as a Query, fetching one page at a time,
+// so that it only holds one page in memory:
+//
+//	iter := table.Query("pk", id).Iter()
+//	var v T
+//	for iter.Next(ctx, &v) {
+//		...
+//	}
+//	if err := iter.Err(); err != nil {
+//		...
+//	}
+type Iter struct {
+	// fetch requests the page starting at startKey, with at most limit items if limit > 0
+	fetch func(ctx context.Context, startKey map[string]types.AttributeValue, limit int) (page, error)
+	dec   *Decoder
+	// maximum number of items to return, or 0
+	limit int
+
+	items   []map[string]types.AttributeValue
+	lastKey map[string]types.AttributeValue
+	started bool
+	n       int
+	err     error
+}
+
+// page is one page of results
+type page struct {
+	items   []map[string]types.AttributeValue
+	lastKey map[string]types.AttributeValue
+}
+
+// Next decodes the next item into out with UnmarshalItem, fetching the next page if needed.
+// It returns false when there are no more items or an error happened; see Err.
+func (it *Iter) Next(ctx context.Context, out interface{}) bool {
+	item, ok := it.next(ctx)
+	if !ok {
+		return false
+	}
+	if err := it.dec.UnmarshalItem(item, out); err != nil {
+		it.err = err
+		return false
+	}
+	return true
+}
+
+// next returns the next item without decoding it
+func (it *Iter) next(ctx context.Context) (map[string]types.AttributeValue, bool) {
+	if !it.fill(ctx) {
+		return nil, false
+	}
+	item := it.items[0]
+	it.items = it.items[1:]
+	it.n++
+	return item, true
+}
+
+// fill fetches pages until some items are buffered. It returns false if there are no more
+// items, the limit was reached or an error happened.
+func (it *Iter) fill(ctx context.Context) bool {
+	if it.err != nil || it.limit > 0 && it.n >= it.limit {
+		return false
+	}
+	for len(it.items) == 0 {
+		if it.started && it.lastKey == nil {
+			return false
+		}
+		if err := ctx.Err(); err != nil {
+			it.err = err
+			return false
+		}
+		limit := 0
+		if it.limit > 0 {
+			limit = it.limit - it.n
+		}
+		pg, err := it.fetch(ctx, it.lastKey, limit)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.started = true
+		it.items, it.lastKey = pg.items, pg.lastKey
+	}
+	return true
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *Iter) Err() error {
+	return it.err
+}
+
+// all decodes the remaining items, appending them to the slice pointed to by out, a page at a time
+func (it *Iter) all(ctx context.Context, out interface{}) error {
+	for it.fill(ctx) {
+		items := it.items
+		if it.limit > 0 && len(items) > it.limit-it.n {
+			items = items[:it.limit-it.n]
+		}
+		it.items = it.items[len(items):]
+		it.n += len(items)
+		if err := it.dec.UnmarshalItems(items, out); err != nil {
+			it.err = err
+		}
+	}
+	return it.err
+}
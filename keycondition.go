@@ -0,0 +1,308 @@
+package fuel
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// KeyCondition is a key condition expression for Query: the partition key equals a value,
+// optionally with a condition on the sort key. Build one with Key and Range,
+// or check a Condition with KeyConditionOf.
+type KeyCondition struct {
+	partition keyComparison
+	sort      *keyComparison
+	// set if the condition is invalid, reported when rendering
+	err error
+}
+
+type keyComparison struct {
+	name string
+	SortKeyCondition
+}
+
+// SortKeyCondition is a condition on a sort key, built with SortEqual, SortLessThan,
+// SortLessThanEqual, SortGreaterThan, SortGreaterThanEqual, SortBetween or SortBeginsWith.
+type SortKeyCondition struct {
+	op     string
+	values []interface{}
+}
+
+// Key is the key condition that the partition key name equals v.
+func Key(name string, v interface{}) KeyCondition {
+	if name == "" {
+		return KeyCondition{err: fmt.Errorf("dynamodb: key condition: empty partition key name")}
+	}
+	return KeyCondition{partition: keyComparison{name: name, SortKeyCondition: SortEqual(v)}}
+}
+
+// Range adds the condition c on the sort key name.
+func (k KeyCondition) Range(name string, c SortKeyCondition) KeyCondition {
+	switch {
+	case k.err != nil:
+		return k
+	case name == "":
+		k.err = fmt.Errorf("dynamodb: key condition: empty sort key name")
+	case name == k.partition.name:
+		k.err = fmt.Errorf("dynamodb: key condition: %s is both the partition and the sort key", name)
+	case k.sort != nil:
+		k.err = fmt.Errorf("dynamodb: key condition: sort key %s has a condition already", k.sort.name)
+	case c.op == "":
+		k.err = fmt.Errorf("dynamodb: key condition: empty condition on sort key %s", name)
+	default:
+		k.sort = &keyComparison{name: name, SortKeyCondition: c}
+	}
+	return k
+}
+
+// SortEqual is the sort key condition key = v.
+func SortEqual(v interface{}) SortKeyCondition {
+	return SortKeyCondition{op: "=", values: []interface{}{v}}
+}
+
+// SortLessThan is the sort key condition key < v.
+func SortLessThan(v interface{}) SortKeyCondition {
+	return SortKeyCondition{op: "<", values: []interface{}{v}}
+}
+
+// SortLessThanEqual is the sort key condition key <= v.
+func SortLessThanEqual(v interface{}) SortKeyCondition {
+	return SortKeyCondition{op: "<=", values: []interface{}{v}}
+}
+
+// SortGreaterThan is the sort key condition key > v.
+func SortGreaterThan(v interface{}) SortKeyCondition {
+	return SortKeyCondition{op: ">", values: []interface{}{v}}
+}
+
+// SortGreaterThanEqual is the sort key condition key >= v.
+func SortGreaterThanEqual(v interface{}) SortKeyCondition {
+	return SortKeyCondition{op: ">=", values: []interface{}{v}}
+}
+
+// SortBetween is the sort key condition key BETWEEN low AND high.
+func SortBetween(low, high interface{}) SortKeyCondition {
+	return SortKeyCondition{op: condBetween, values: []interface{}{low, high}}
+}
+
+// SortBeginsWith is the sort key condition begins_with(key, prefix),
+// where prefix is a string or a []byte.
+func SortBeginsWith(prefix interface{}) SortKeyCondition {
+	return SortKeyCondition{op: "begins_with", values: []interface{}{prefix}}
+}
+
+// KeyConditionOf checks that c can be used as a key condition, and converts it:
+// c must be Equal(Name(partitionKey), v), optionally And-ed with one comparison,
+// Between or BeginsWith on the sort key. Other conditions, such as Contains or Or,
+// and comparisons with anything but values make the key condition fail to render.
+func KeyConditionOf(c Condition) KeyCondition {
+	if c.err != nil {
+		return KeyCondition{err: c.err}
+	}
+	conds := []Condition{c}
+	if c.op == condAnd {
+		conds = c.conds
+	}
+	if len(conds) > 2 {
+		return KeyCondition{err: fmt.Errorf("dynamodb: key condition: at most two conditions can be combined, got %d", len(conds))}
+	}
+
+	cmps := make([]keyComparison, 0, len(conds))
+	for _, sub := range conds {
+		cmp, err := keyComparisonOf(sub)
+		if err != nil {
+			return KeyCondition{err: err}
+		}
+		cmps = append(cmps, cmp)
+	}
+	// the partition key is compared with =, and the sort key may be too
+	if cmps[0].op != "=" && len(cmps) == 2 {
+		cmps[0], cmps[1] = cmps[1], cmps[0]
+	}
+	if cmps[0].op != "=" {
+		return KeyCondition{err: fmt.Errorf("dynamodb: key condition: partition key needs an equality condition")}
+	}
+	k := Key(cmps[0].name, cmps[0].values[0])
+	if len(cmps) == 2 {
+		k = k.Range(cmps[1].name, cmps[1].SortKeyCondition)
+	}
+	return k
+}
+
+func keyComparisonOf(c Condition) (keyComparison, error) {
+	switch c.op {
+	case "=", "<", "<=", ">", ">=", condBetween, "begins_with":
+	case "":
+		return keyComparison{}, fmt.Errorf("dynamodb: key condition: empty condition")
+	default:
+		return keyComparison{}, fmt.Errorf("dynamodb: key condition: %s can't be used on keys", c.op)
+	}
+
+	name, ok := c.operands[0].(nameOperand)
+	if !ok {
+		return keyComparison{}, fmt.Errorf("dynamodb: key condition: %s needs a key attribute on the left, got %T", c.op, c.operands[0])
+	}
+	elems, err := parsePath(string(name))
+	if err != nil {
+		return keyComparison{}, err
+	}
+	if len(elems) != 1 {
+		return keyComparison{}, fmt.Errorf("dynamodb: key condition: %s is a document path, not a key attribute", name)
+	}
+
+	cmp := keyComparison{name: elems[0].name, SortKeyCondition: SortKeyCondition{op: c.op}}
+	for _, op := range c.operands[1:] {
+		v, ok := op.(valueOperand)
+		if !ok {
+			return keyComparison{}, fmt.Errorf("dynamodb: key condition: %s can only be compared with values, got %T", name, op)
+		}
+		cmp.values = append(cmp.values, v.v)
+	}
+	return cmp, nil
+}
+
+// Render returns the key condition expression, adding the names and values it uses to p.
+// Key values must encode as non-empty S, N or B values, and begins_with needs S or B.
+func (k KeyCondition) Render(p *Placeholders) (string, error) {
+	if k.err != nil {
+		return "", k.err
+	}
+	if k.partition.name == "" {
+		return "", fmt.Errorf("dynamodb: key condition: no partition key")
+	}
+
+	expr, err := k.partition.render(p)
+	if err != nil {
+		return "", err
+	}
+	if k.sort != nil {
+		sort, err := k.sort.render(p)
+		if err != nil {
+			return "", err
+		}
+		expr += " AND " + sort
+	}
+	return expr, nil
+}
+
+func (kc keyComparison) render(p *Placeholders) (string, error) {
+	values := make([]string, 0, len(kc.values))
+	for _, v := range kc.values {
+		av, err := p.encoder().marshal(v, 0)
+		if err != nil {
+			return "", err
+		}
+		switch x := av.(type) {
+		case *types.AttributeValueMemberS:
+		case *types.AttributeValueMemberB:
+		case *types.AttributeValueMemberN:
+			if kc.op == "begins_with" {
+				return "", fmt.Errorf("dynamodb: key condition: begins_with on %s needs a string or binary prefix, got N", kc.name)
+			}
+		case nil:
+			return "", fmt.Errorf("dynamodb: key condition: value %#v for %s encodes to nothing", v, kc.name)
+		default:
+			return "", fmt.Errorf("dynamodb: key condition: value for %s must encode as S, N or B, got %T", kc.name, x)
+		}
+		values = append(values, p.value(av))
+	}
+
+	name := p.Name(kc.name)
+	switch kc.op {
+	case condBetween:
+		return name + " BETWEEN " + values[0] + " AND " + values[1], nil
+	case "begins_with":
+		return "begins_with(" + name + ", " + values[0] + ")", nil
+	}
+	return name + " " + kc.op + " " + values[0], nil
+}
+
+// keyNames returns the key attributes the condition uses
+func (k KeyCondition) keyNames() []string {
+	names := []string{k.partition.name}
+	if k.sort != nil {
+		names = append(names, k.sort.name)
+	}
+	return names
+}
+
+// QueryInput builds the input for querying tableName with the key condition and the filter,
+// which may be empty. Their placeholders are merged into one set of
+// ExpressionAttributeNames and ExpressionAttributeValues.
+// DynamoDB doesn't allow filters on key attributes, so filters using them fail.
+func (k KeyCondition) QueryInput(tableName string, filter Condition) (*dynamodb.QueryInput, error) {
+	var p Placeholders
+	in, err := k.queryInput(tableName, filter, &p)
+	if err != nil {
+		return nil, err
+	}
+	in.ExpressionAttributeNames = p.Names
+	in.ExpressionAttributeValues = p.Values
+	return in, nil
+}
+
+// queryInput is like QueryInput, leaving the placeholders in p
+func (k KeyCondition) queryInput(tableName string, filter Condition, p *Placeholders) (*dynamodb.QueryInput, error) {
+	keyExpr, err := k.Render(p)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range k.keyNames() {
+		if filter.uses(key) {
+			return nil, fmt.Errorf("dynamodb: query: filter can't use key attribute %s", key)
+		}
+	}
+	filterExpr, err := filter.Render(p)
+	if err != nil {
+		return nil, err
+	}
+
+	in := &dynamodb.QueryInput{
+		TableName:              aws.String(tableName),
+		KeyConditionExpression: aws.String(keyExpr),
+	}
+	if filterExpr != "" {
+		in.FilterExpression = aws.String(filterExpr)
+	}
+	return in, nil
+}
+
+// uses reports whether c refers to the top-level attribute name
+func (c Condition) uses(name string) bool {
+	for _, sub := range c.conds {
+		if sub.uses(name) {
+			return true
+		}
+	}
+	for _, op := range c.operands {
+		if operandUses(op, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func operandUses(op Operand, name string) bool {
+	var path string
+	switch x := op.(type) {
+	case nameOperand:
+		path = string(x)
+	case sizeOperand:
+		path = string(x)
+	case pathOperand:
+		return len(x) > 0 && x[0].name == name
+	case funcOperand:
+		for _, op := range x.operands {
+			if operandUses(op, name) {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+	elems, err := parsePath(path)
+	return err == nil && elems[0].name == name
+}
@@ -0,0 +1,219 @@
+package fuel
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestKeyConditionRender(t *testing.T) {
+	tests := []struct {
+		name       string
+		cond       KeyCondition
+		expr       string
+		names      map[string]string
+		values     map[string]types.AttributeValue
+		shouldFail bool
+	}{
+		{
+			name:   "partition key",
+			cond:   Key("pk", "user#1"),
+			expr:   "pk = :v0",
+			values: map[string]types.AttributeValue{":v0": &types.AttributeValueMemberS{Value: "user#1"}},
+		},
+		{
+			name:  "sort key comparison",
+			cond:  Key("pk", "user#1").Range("timestamp", SortGreaterThanEqual(100)),
+			expr:  "pk = :v0 AND #n0 >= :v1",
+			names: map[string]string{"#n0": "timestamp"},
+			values: map[string]types.AttributeValue{
+				":v0": &types.AttributeValueMemberS{Value: "user#1"},
+				":v1": &types.AttributeValueMemberN{Value: "100"},
+			},
+		},
+		{
+			name: "sort key between",
+			cond: Key("pk", 1).Range("sk", SortBetween("a", "m")),
+			expr: "pk = :v0 AND sk BETWEEN :v1 AND :v2",
+			values: map[string]types.AttributeValue{
+				":v0": &types.AttributeValueMemberN{Value: "1"},
+				":v1": &types.AttributeValueMemberS{Value: "a"},
+				":v2": &types.AttributeValueMemberS{Value: "m"},
+			},
+		},
+		{
+			name: "sort key begins_with",
+			cond: Key("pk", []byte{1}).Range("sk", SortBeginsWith("ORDER#")),
+			expr: "pk = :v0 AND begins_with(sk, :v1)",
+			values: map[string]types.AttributeValue{
+				":v0": &types.AttributeValueMemberB{Value: []byte{1}},
+				":v1": &types.AttributeValueMemberS{Value: "ORDER#"},
+			},
+		},
+		{
+			name:   "names with dots are attribute names",
+			cond:   Key("user.id", "x"),
+			expr:   "#n0 = :v0",
+			names:  map[string]string{"#n0": "user.id"},
+			values: map[string]types.AttributeValue{":v0": &types.AttributeValueMemberS{Value: "x"}},
+		},
+		{
+			name: "from condition",
+			cond: KeyConditionOf(LessThan(Name("sk"), "b").And(Equal(Name("pk"), "a"))),
+			expr: "pk = :v0 AND sk < :v1",
+			values: map[string]types.AttributeValue{
+				":v0": &types.AttributeValueMemberS{Value: "a"},
+				":v1": &types.AttributeValueMemberS{Value: "b"},
+			},
+		},
+		{
+			name: "from condition with begins_with",
+			cond: KeyConditionOf(And(Equal(Name("pk"), "a"), BeginsWith("sk", "x"))),
+			expr: "pk = :v0 AND begins_with(sk, :v1)",
+			values: map[string]types.AttributeValue{
+				":v0": &types.AttributeValueMemberS{Value: "a"},
+				":v1": &types.AttributeValueMemberS{Value: "x"},
+			},
+		},
+		{
+			name:       "zero",
+			cond:       KeyCondition{},
+			shouldFail: true,
+		},
+		{
+			name:       "same key twice",
+			cond:       Key("pk", "a").Range("pk", SortEqual("b")),
+			shouldFail: true,
+		},
+		{
+			name:       "two sort conditions",
+			cond:       Key("pk", "a").Range("sk", SortLessThan(1)).Range("sk", SortGreaterThan(0)),
+			shouldFail: true,
+		},
+		{
+			name:       "empty sort condition",
+			cond:       Key("pk", "a").Range("sk", SortKeyCondition{}),
+			shouldFail: true,
+		},
+		{
+			name:       "empty string",
+			cond:       Key("pk", ""),
+			shouldFail: true,
+		},
+		{
+			name:       "not a key type",
+			cond:       Key("pk", true),
+			shouldFail: true,
+		},
+		{
+			name:       "begins_with a number",
+			cond:       Key("pk", "a").Range("sk", SortBeginsWith(1)),
+			shouldFail: true,
+		},
+		{
+			name:       "contains",
+			cond:       KeyConditionOf(Equal(Name("pk"), "a").And(Contains("sk", "x"))),
+			shouldFail: true,
+		},
+		{
+			name:       "or",
+			cond:       KeyConditionOf(Equal(Name("pk"), "a").Or(Equal(Name("pk"), "b"))),
+			shouldFail: true,
+		},
+		{
+			name:       "not equal",
+			cond:       KeyConditionOf(NotEqual(Name("pk"), "a")),
+			shouldFail: true,
+		},
+		{
+			name:       "no partition key",
+			cond:       KeyConditionOf(And(LessThan(Name("pk"), "a"), GreaterThan(Name("sk"), "b"))),
+			shouldFail: true,
+		},
+		{
+			name:       "three conditions",
+			cond:       KeyConditionOf(And(Equal(Name("pk"), "a"), Equal(Name("sk"), "b"), Equal(Name("c"), "c"))),
+			shouldFail: true,
+		},
+		{
+			name:       "size",
+			cond:       KeyConditionOf(Equal(Size("pk"), 1)),
+			shouldFail: true,
+		},
+		{
+			name:       "compare names",
+			cond:       KeyConditionOf(Equal(Name("pk"), Name("sk"))),
+			shouldFail: true,
+		},
+		{
+			name:       "document path",
+			cond:       KeyConditionOf(Equal(Name("pk.a"), "a")),
+			shouldFail: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p Placeholders
+			expr, err := tt.cond.Render(&p)
+			if tt.shouldFail {
+				if err == nil {
+					t.Fatalf("expected error, got %q", expr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if expr != tt.expr {
+				t.Errorf("expression missmatch: want %q, got %q", tt.expr, expr)
+			}
+			if diff := cmp.Diff(tt.names, p.Names); diff != "" {
+				t.Errorf("names missmatch (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.values, p.Values); diff != "" {
+				t.Errorf("values missmatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestKeyConditionQueryInput(t *testing.T) {
+	key := Key("pk", "user#1").Range("sk", SortBeginsWith("ORDER#"))
+	got, err := key.QueryInput("orders", And(Equal(Name("Status"), "open"), GreaterThan(Size("lines"), 0)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &dynamodb.QueryInput{
+		TableName:              aws.String("orders"),
+		KeyConditionExpression: aws.String("pk = :v0 AND begins_with(sk, :v1)"),
+		FilterExpression:       aws.String("#n0 = :v2 AND size(#n1) > :v3"),
+		ExpressionAttributeNames: map[string]string{
+			"#n0": "Status", "#n1": "lines",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":v0": &types.AttributeValueMemberS{Value: "user#1"},
+			":v1": &types.AttributeValueMemberS{Value: "ORDER#"},
+			":v2": &types.AttributeValueMemberS{Value: "open"},
+			":v3": &types.AttributeValueMemberN{Value: "0"},
+		},
+	}
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreUnexported(dynamodb.QueryInput{}, types.AttributeValueMemberS{}, types.AttributeValueMemberN{})); diff != "" {
+		t.Errorf("missmatch (-want, +got):\n%s", diff)
+	}
+
+	got, err = key.QueryInput("orders", Condition{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.FilterExpression != nil {
+		t.Errorf("expected no filter, got %q", *got.FilterExpression)
+	}
+
+	if _, err := key.QueryInput("orders", Not(AttributeExists("sk.part"))); err == nil {
+		t.Error("filter on key: expected error, got nil")
+	}
+}
@@ -0,0 +1,289 @@
+package fuel
+
+import (
+	"reflect"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// Coercion describes a conversion a lenient Decoder applied to an attribute value
+// whose DynamoDB type did not match the Go type it was decoded into.
+type Coercion struct {
+	// From is the original attribute type, such as "number"
+	From string
+	// To is the attribute type the value was converted to
+	To string
+	// Type is the Go type being decoded into
+	Type reflect.Type
+	// Path is the document path of the attribute, such as "orders[0].total".
+	// It is empty for a value decoded on its own by Unmarshal.
+	Path string
+}
+
+// Lenient enables type coercion when decoding: S and N are converted into each other,
+// S "true"/"false" into BOOL, scalars into single-element lists (and vice versa),
+// and lists of strings, numbers or binaries into the matching sets.
+// report is called for every coercion performed and may be nil.
+func Lenient(report func(Coercion)) DecoderOption {
+	return decoderOptionFunc(func(d *Decoder) {
+		d.lenient = true
+		d.onCoerce = report
+	})
+}
+
+var (
+	nilAv  types.AttributeValue
+	avType = reflect.TypeOf(&nilAv).Elem()
+
+	nilUm  Unmarshaler
+	umType = reflect.TypeOf(&nilUm).Elem()
+)
+
+// decodePath is the document path of the value being decoded, kept for coercion reports.
+// It is only built when there is a report function; otherwise it stays nil.
+type decodePath struct {
+	parent *decodePath
+	elem   pathElem
+}
+
+func (d *Decoder) field(parent *decodePath, name string) *decodePath {
+	if d.onCoerce == nil {
+		return nil
+	}
+	return &decodePath{parent: parent, elem: pathElem{name: name}}
+}
+
+func (d *Decoder) index(parent *decodePath, i int) *decodePath {
+	if d.onCoerce == nil {
+		return nil
+	}
+	return &decodePath{parent: parent, elem: pathElem{index: i}}
+}
+
+func (p *decodePath) String() string {
+	var elems []pathElem
+	for ; p != nil; p = p.parent {
+		elems = append(elems, p.elem)
+	}
+	for i, j := 0, len(elems)-1; i < j; i, j = i+1, j-1 {
+		elems[i], elems[j] = elems[j], elems[i]
+	}
+	return formatPath(elems)
+}
+
+func (d *Decoder) coerce(av types.AttributeValue, rt reflect.Type, path *decodePath) types.AttributeValue {
+	coerced, ok := coerce(av, rt)
+	if !ok {
+		return av
+	}
+	if d.onCoerce != nil {
+		d.onCoerce(Coercion{
+			From: avTypeName(av),
+			To:   avTypeName(coerced),
+			Type: rt,
+			Path: path.String(),
+		})
+	}
+	return coerced
+}
+
+// coerce converts av into the shape the strict decoder expects for rt.
+// It reports false if av needs no conversion or can't be converted.
+func coerce(av types.AttributeValue, rt reflect.Type) (types.AttributeValue, bool) {
+	ptr := reflect.PtrTo(rt)
+	switch {
+	case rt.Implements(avType) || ptr.Implements(avType):
+		return nil, false
+	case rt.Implements(umType) || ptr.Implements(umType):
+		// custom unmarshalers get the raw data
+		return nil, false
+	case rt.Implements(tumType) || ptr.Implements(tumType):
+		return unwrapSingle(av, rt)
+	}
+
+	switch rt.Kind() {
+	case reflect.Bool:
+		if x, ok := av.(*types.AttributeValueMemberS); ok {
+			switch x.Value {
+			case "true":
+				return &types.AttributeValueMemberBOOL{Value: true}, true
+			case "false":
+				return &types.AttributeValueMemberBOOL{Value: false}, true
+			}
+		}
+	case reflect.Int, reflect.Int64, reflect.Int32, reflect.Int16, reflect.Int8,
+		reflect.Uint, reflect.Uint64, reflect.Uint32, reflect.Uint16, reflect.Uint8,
+		reflect.Float64, reflect.Float32:
+		if x, ok := av.(*types.AttributeValueMemberS); ok {
+			if isDecimal(x.Value) {
+				return &types.AttributeValueMemberN{Value: x.Value}, true
+			}
+		}
+	case reflect.String:
+		switch x := av.(type) {
+		case *types.AttributeValueMemberN:
+			return &types.AttributeValueMemberS{Value: x.Value}, true
+		case *types.AttributeValueMemberBOOL:
+			return &types.AttributeValueMemberS{Value: strconv.FormatBool(x.Value)}, true
+		}
+	case reflect.Slice, reflect.Array:
+		if rt.Elem().Kind() == reflect.Uint8 {
+			// binary data
+			return unwrapSingle(av, rt)
+		}
+		switch x := av.(type) {
+		case *types.AttributeValueMemberS, *types.AttributeValueMemberN, *types.AttributeValueMemberBOOL,
+			*types.AttributeValueMemberB, *types.AttributeValueMemberM:
+			return &types.AttributeValueMemberL{Value: []types.AttributeValue{x}}, true
+		case *types.AttributeValueMemberSS, *types.AttributeValueMemberNS, *types.AttributeValueMemberBS:
+			if rt.Kind() == reflect.Array {
+				return set2list(x), true
+			}
+		}
+		return nil, false
+	case reflect.Map:
+		if !isSetType(rt) {
+			return unwrapSingle(av, rt)
+		}
+		switch x := av.(type) {
+		case *types.AttributeValueMemberS:
+			return &types.AttributeValueMemberSS{Value: []string{x.Value}}, true
+		case *types.AttributeValueMemberN:
+			return &types.AttributeValueMemberNS{Value: []string{x.Value}}, true
+		case *types.AttributeValueMemberB:
+			return &types.AttributeValueMemberBS{Value: [][]byte{x.Value}}, true
+		case *types.AttributeValueMemberL:
+			return list2set(x)
+		}
+		return nil, false
+	case reflect.Ptr, reflect.Interface:
+		return nil, false
+	}
+
+	return unwrapSingle(av, rt)
+}
+
+// unwrapSingle converts a single-element list into its element
+func unwrapSingle(av types.AttributeValue, rt reflect.Type) (types.AttributeValue, bool) {
+	avL, ok := av.(*types.AttributeValueMemberL)
+	if !ok || len(avL.Value) != 1 {
+		return nil, false
+	}
+	inner := avL.Value[0]
+	if coerced, ok := coerce(inner, rt); ok {
+		return coerced, true
+	}
+	return inner, true
+}
+
+// list2set converts a list whose elements all share a scalar type into the matching set
+func list2set(avL *types.AttributeValueMemberL) (types.AttributeValue, bool) {
+	if len(avL.Value) == 0 {
+		return nil, false
+	}
+	switch avL.Value[0].(type) {
+	case *types.AttributeValueMemberS:
+		ss := make([]string, 0, len(avL.Value))
+		for _, v := range avL.Value {
+			avS, ok := v.(*types.AttributeValueMemberS)
+			if !ok {
+				return nil, false
+			}
+			ss = append(ss, avS.Value)
+		}
+		return &types.AttributeValueMemberSS{Value: ss}, true
+	case *types.AttributeValueMemberN:
+		ns := make([]string, 0, len(avL.Value))
+		for _, v := range avL.Value {
+			avN, ok := v.(*types.AttributeValueMemberN)
+			if !ok {
+				return nil, false
+			}
+			ns = append(ns, avN.Value)
+		}
+		return &types.AttributeValueMemberNS{Value: ns}, true
+	case *types.AttributeValueMemberB:
+		bs := make([][]byte, 0, len(avL.Value))
+		for _, v := range avL.Value {
+			avB, ok := v.(*types.AttributeValueMemberB)
+			if !ok {
+				return nil, false
+			}
+			bs = append(bs, avB.Value)
+		}
+		return &types.AttributeValueMemberBS{Value: bs}, true
+	}
+	return nil, false
+}
+
+// set2list converts a set into a list of its members
+func set2list(av types.AttributeValue) *types.AttributeValueMemberL {
+	var list []types.AttributeValue
+	switch x := av.(type) {
+	case *types.AttributeValueMemberSS:
+		list = make([]types.AttributeValue, 0, len(x.Value))
+		for _, s := range x.Value {
+			list = append(list, &types.AttributeValueMemberS{Value: s})
+		}
+	case *types.AttributeValueMemberNS:
+		list = make([]types.AttributeValue, 0, len(x.Value))
+		for _, n := range x.Value {
+			list = append(list, &types.AttributeValueMemberN{Value: n})
+		}
+	case *types.AttributeValueMemberBS:
+		list = make([]types.AttributeValue, 0, len(x.Value))
+		for _, b := range x.Value {
+			list = append(list, &types.AttributeValueMemberB{Value: b})
+		}
+	}
+	return &types.AttributeValueMemberL{Value: list}
+}
+
+// isSetType reports whether rt is a map that can hold a set (values are bool or struct{})
+func isSetType(rt reflect.Type) bool {
+	if rt.Kind() != reflect.Map {
+		return false
+	}
+	elem := rt.Elem()
+	return elem.Kind() == reflect.Bool || elem.Kind() == reflect.Struct && elem.NumField() == 0
+}
+
+// isDecimal reports whether s is a plain decimal number such as "-1.5" or "2e10".
+// Unlike strconv.ParseFloat it rejects "NaN", "Inf", hex and underscores, which DynamoDB can't store.
+func isDecimal(s string) bool {
+	i := 0
+	if i < len(s) && (s[i] == '+' || s[i] == '-') {
+		i++
+	}
+	digits := 0
+	for ; i < len(s) && isDigit(s[i]); i++ {
+		digits++
+	}
+	if i < len(s) && s[i] == '.' {
+		i++
+		for ; i < len(s) && isDigit(s[i]); i++ {
+			digits++
+		}
+	}
+	if digits == 0 {
+		return false
+	}
+	if i < len(s) && (s[i] == 'e' || s[i] == 'E') {
+		i++
+		if i < len(s) && (s[i] == '+' || s[i] == '-') {
+			i++
+		}
+		start := i
+		for ; i < len(s) && isDigit(s[i]); i++ {
+		}
+		if i == start {
+			return false
+		}
+	}
+	return i == len(s)
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
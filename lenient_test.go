@@ -0,0 +1,248 @@
+package fuel
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/go-cmp/cmp"
+)
+
+type lenientItem struct {
+	Count   int
+	Price   float64
+	Name    string
+	Flag    bool
+	Tags    []string
+	Set     map[string]struct{}
+	NumSet  map[int]bool
+	Arr     [2]string
+	Nested  struct{ OK bool }
+	Created time.Time
+}
+
+var lenientDecodeTests = []struct {
+	name  string
+	given map[string]types.AttributeValue
+	want  lenientItem
+	from  []string
+}{
+	{
+		name: "S to N",
+		given: map[string]types.AttributeValue{
+			"Count": &types.AttributeValueMemberS{Value: "42"},
+			"Price": &types.AttributeValueMemberS{Value: "1.5"},
+		},
+		want: lenientItem{Count: 42, Price: 1.5},
+		from: []string{"string", "string"},
+	},
+	{
+		name: "N to S",
+		given: map[string]types.AttributeValue{
+			"Name": &types.AttributeValueMemberN{Value: "123"},
+		},
+		want: lenientItem{Name: "123"},
+		from: []string{"number"},
+	},
+	{
+		name: "S to BOOL",
+		given: map[string]types.AttributeValue{
+			"Flag": &types.AttributeValueMemberS{Value: "true"},
+		},
+		want: lenientItem{Flag: true},
+		from: []string{"string"},
+	},
+	{
+		name: "single-element list to scalar",
+		given: map[string]types.AttributeValue{
+			"Count": &types.AttributeValueMemberL{Value: []types.AttributeValue{&types.AttributeValueMemberS{Value: "7"}}},
+			"Nested": &types.AttributeValueMemberL{Value: []types.AttributeValue{&types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+				"OK": &types.AttributeValueMemberBOOL{Value: true},
+			}}}},
+			"Created": &types.AttributeValueMemberL{Value: []types.AttributeValue{&types.AttributeValueMemberS{Value: "2019-01-01T00:00:00Z"}}},
+		},
+		want: lenientItem{
+			Count:   7,
+			Nested:  struct{ OK bool }{OK: true},
+			Created: time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		from: []string{"list", "list", "list"},
+	},
+	{
+		name: "scalar to list",
+		given: map[string]types.AttributeValue{
+			"Tags": &types.AttributeValueMemberS{Value: "A"},
+		},
+		want: lenientItem{Tags: []string{"A"}},
+		from: []string{"string"},
+	},
+	{
+		name: "list to set",
+		given: map[string]types.AttributeValue{
+			"Set": &types.AttributeValueMemberL{Value: []types.AttributeValue{
+				&types.AttributeValueMemberS{Value: "A"},
+				&types.AttributeValueMemberS{Value: "B"},
+			}},
+			"NumSet": &types.AttributeValueMemberL{Value: []types.AttributeValue{
+				&types.AttributeValueMemberN{Value: "1"},
+			}},
+		},
+		want: lenientItem{
+			Set:    map[string]struct{}{"A": {}, "B": {}},
+			NumSet: map[int]bool{1: true},
+		},
+		from: []string{"list", "list"},
+	},
+	{
+		name: "set to list",
+		given: map[string]types.AttributeValue{
+			"Arr": &types.AttributeValueMemberSS{Value: []string{"A", "B"}},
+		},
+		want: lenientItem{Arr: [2]string{"A", "B"}},
+		from: []string{"string set"},
+	},
+	{
+		name: "matching types are not coerced",
+		given: map[string]types.AttributeValue{
+			"Count": &types.AttributeValueMemberN{Value: "1"},
+			"Tags":  &types.AttributeValueMemberSS{Value: []string{"A"}},
+		},
+		want: lenientItem{Count: 1, Tags: []string{"A"}},
+	},
+}
+
+func TestLenientUnmarshalItem(t *testing.T) {
+	for _, tc := range lenientDecodeTests {
+		var from []string
+		dec := NewDecoder(Lenient(func(c Coercion) {
+			from = append(from, c.From)
+		}))
+
+		var got lenientItem
+		if err := dec.UnmarshalItem(tc.given, &got); err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+			continue
+		}
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Errorf("%s: missmatch (-want, +got):\n%s", tc.name, diff)
+		}
+		if len(from) != len(tc.from) {
+			t.Errorf("%s: coercion count: want %d, got %d (%v)", tc.name, len(tc.from), len(from), from)
+		}
+	}
+}
+
+func TestLenientStrict(t *testing.T) {
+	item := map[string]types.AttributeValue{
+		"Count": &types.AttributeValueMemberS{Value: "42"},
+	}
+
+	var got lenientItem
+	if err := UnmarshalItem(item, &got); err == nil {
+		t.Error("expected error from strict decoder, got nil")
+	}
+
+	var report Coercion
+	dec := NewDecoder(Lenient(func(c Coercion) {
+		report = c
+	}))
+	if err := dec.UnmarshalItem(item, &got); err != nil {
+		t.Fatal(err)
+	}
+	want := Coercion{From: "string", To: "number", Type: reflect.TypeOf(0), Path: "Count"}
+	if report != want {
+		t.Errorf("bad coercion report: want %+v, got %+v", want, report)
+	}
+}
+
+func TestLenientPath(t *testing.T) {
+	type order struct {
+		Total int
+	}
+	type customer struct {
+		Orders []order
+		Scores map[string]int
+		Tags   [1]string
+	}
+	item := map[string]types.AttributeValue{
+		"Orders": &types.AttributeValueMemberL{Value: []types.AttributeValue{
+			&types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+				"Total": &types.AttributeValueMemberN{Value: "1"},
+			}},
+			&types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+				"Total": &types.AttributeValueMemberS{Value: "2"},
+			}},
+		}},
+		"Scores": &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+			"a.b": &types.AttributeValueMemberS{Value: "3"},
+		}},
+		"Tags": &types.AttributeValueMemberL{Value: []types.AttributeValue{
+			&types.AttributeValueMemberN{Value: "4"},
+		}},
+	}
+
+	var paths []string
+	dec := NewDecoder(Lenient(func(c Coercion) {
+		paths = append(paths, c.Path)
+	}))
+	var got customer
+	if err := dec.UnmarshalItem(item, &got); err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(paths)
+	want := []string{`Orders[1].Total`, `Scores.a\.b`, `Tags[0]`}
+	if diff := cmp.Diff(want, paths); diff != "" {
+		t.Errorf("missmatch (-want, +got):\n%s", diff)
+	}
+
+	var n int
+	var report Coercion
+	dec = NewDecoder(Lenient(func(c Coercion) {
+		report = c
+	}))
+	if err := dec.Unmarshal(&types.AttributeValueMemberS{Value: "5"}, &n); err != nil {
+		t.Fatal(err)
+	}
+	if n != 5 || report.From != "string" || report.Path != "" {
+		t.Errorf("bad lone value: got %d, path %q", n, report.Path)
+	}
+}
+
+func TestLenientDecimal(t *testing.T) {
+	tests := []struct {
+		in string
+		ok bool
+	}{
+		{"42", true},
+		{"-1.5", true},
+		{"+.5", true},
+		{"5.", true},
+		{"2e10", true},
+		{"1.5E-3", true},
+		{"", false},
+		{".", false},
+		{"-", false},
+		{"1e", false},
+		{"NaN", false},
+		{"Inf", false},
+		{"-Infinity", false},
+		{"0x1p4", false},
+		{"1_000", false},
+		{" 1", false},
+	}
+	for _, tc := range tests {
+		item := map[string]types.AttributeValue{
+			"Price": &types.AttributeValueMemberS{Value: tc.in},
+		}
+		var got lenientItem
+		err := NewDecoder(Lenient(nil)).UnmarshalItem(item, &got)
+		if tc.ok && err != nil {
+			t.Errorf("%q: unexpected error: %v", tc.in, err)
+		}
+		if !tc.ok && err == nil {
+			t.Errorf("%q: expected error, got %v", tc.in, got.Price)
+		}
+	}
+}
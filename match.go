@@ -0,0 +1,76 @@
+package fuel
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// NameMatching controls how a Decoder matches attribute names to struct fields
+// when there is no exact match. Exact matches are always preferred.
+type NameMatching int
+
+const (
+	// MatchExact only matches attribute names that are equal to a field's name. This is the default.
+	MatchExact NameMatching = iota
+	// MatchCaseInsensitive also matches names that differ only in case,
+	// like encoding/json does, so userId, UserID and userid are the same.
+	MatchCaseInsensitive
+	// MatchNormalized is like MatchCaseInsensitive, but also ignores underscores and dashes,
+	// so user_id, user-id and userId are the same.
+	MatchNormalized
+)
+
+// MatchNames sets how a Decoder matches attribute names that aren't an exact match for any field.
+func MatchNames(matching NameMatching) DecoderOption {
+	return decoderOptionFunc(func(d *Decoder) {
+		d.matching = matching
+	})
+}
+
+// fold normalizes name for inexact matching
+func (d *Decoder) fold(name string) string {
+	switch d.matching {
+	case MatchCaseInsensitive:
+		return strings.ToLower(name)
+	case MatchNormalized:
+		return strings.Map(func(r rune) rune {
+			if r == '_' || r == '-' {
+				return -1
+			}
+			return r
+		}, strings.ToLower(name))
+	}
+	return name
+}
+
+// foldKeys maps the normalized form of each attribute name in item to the name itself.
+// Names that exactly match one of the fields (or its aliases) are left out, like encoding/json
+// does, so that they can't be taken by another field inexactly.
+// If several names normalize to the same form, the lowest one in sort order is used.
+func (d *Decoder) foldKeys(item map[string]types.AttributeValue, fields []field) map[string]string {
+	exact := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		exact[f.name] = true
+		for _, alias := range f.aliases {
+			exact[alias] = true
+		}
+	}
+	keys := make([]string, 0, len(item))
+	for k := range item {
+		if !exact[k] {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	folded := make(map[string]string, len(keys))
+	for _, k := range keys {
+		f := d.fold(k)
+		if _, ok := folded[f]; !ok {
+			folded[f] = k
+		}
+	}
+	return folded
+}
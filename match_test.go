@@ -0,0 +1,89 @@
+package fuel
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/go-cmp/cmp"
+)
+
+type matchItem struct {
+	UserID string
+	Email  string `dynamodb:"email_address"`
+	Legacy string `dynamodb:"legacy,alias=OldName"`
+}
+
+func TestMatchNames(t *testing.T) {
+	tests := []struct {
+		name     string
+		matching NameMatching
+		given    map[string]types.AttributeValue
+		want     matchItem
+	}{
+		{
+			name: "exact",
+			given: map[string]types.AttributeValue{
+				"userid":       &types.AttributeValueMemberS{Value: "u"},
+				"emailAddress": &types.AttributeValueMemberS{Value: "e"},
+			},
+			want: matchItem{},
+		},
+		{
+			name:     "case insensitive",
+			matching: MatchCaseInsensitive,
+			given: map[string]types.AttributeValue{
+				"userid":       &types.AttributeValueMemberS{Value: "u"},
+				"emailAddress": &types.AttributeValueMemberS{Value: "e"},
+				"oldname":      &types.AttributeValueMemberS{Value: "l"},
+			},
+			want: matchItem{UserID: "u", Legacy: "l"},
+		},
+		{
+			name:     "normalized",
+			matching: MatchNormalized,
+			given: map[string]types.AttributeValue{
+				"user_id":      &types.AttributeValueMemberS{Value: "u"},
+				"emailAddress": &types.AttributeValueMemberS{Value: "e"},
+			},
+			want: matchItem{UserID: "u", Email: "e"},
+		},
+		{
+			name:     "exact match preferred",
+			matching: MatchCaseInsensitive,
+			given: map[string]types.AttributeValue{
+				"USERID": &types.AttributeValueMemberS{Value: "upper"},
+				"UserID": &types.AttributeValueMemberS{Value: "exact"},
+				"userid": &types.AttributeValueMemberS{Value: "lower"},
+			},
+			want: matchItem{UserID: "exact"},
+		},
+	}
+
+	for _, tc := range tests {
+		var got matchItem
+		if err := NewDecoder(MatchNames(tc.matching)).UnmarshalItem(tc.given, &got); err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+			continue
+		}
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Errorf("%s: missmatch (-want, +got):\n%s", tc.name, diff)
+		}
+	}
+}
+
+func TestMatchNamesExactBelongsToOtherField(t *testing.T) {
+	type item struct {
+		ID string
+		Id string
+	}
+	given := map[string]types.AttributeValue{
+		"Id": &types.AttributeValueMemberS{Value: "x"},
+	}
+	var got item
+	if err := NewDecoder(MatchNames(MatchCaseInsensitive)).UnmarshalItem(given, &got); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(item{Id: "x"}, got); diff != "" {
+		t.Errorf("missmatch (-want, +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,138 @@
+package fuel
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// VersionAttribute is the attribute holding the schema version of items
+// whose type has migrations registered. Items without it are version 0.
+const VersionAttribute = "_v"
+
+// Migration upgrades an item from one schema version to the next.
+// The item passed in may be modified and returned.
+type Migration func(item map[string]types.AttributeValue) (map[string]types.AttributeValue, error)
+
+// schema holds the migrations of a type. It is never modified once registered:
+// RegisterMigration replaces it with an updated copy, so lookups can use it without locking.
+type schema struct {
+	current    int
+	migrations map[int]schemaStep // by from version
+}
+
+type schemaStep struct {
+	to int
+	fn Migration
+}
+
+var schemas = struct {
+	sync.RWMutex
+	byType map[reflect.Type]*schema
+}{byType: make(map[reflect.Type]*schema)}
+
+// RegisterMigration registers fn to upgrade items of type t from version from to version to.
+// Once a type has migrations, its current version is the highest registered to version:
+// MarshalItem stamps items with it, and decoding applies migrations in sequence
+// to items of older versions before unmarshaling them.
+// It panics if to isn't greater than from, or a migration from this version already exists.
+func RegisterMigration(t reflect.Type, from, to int, fn Migration) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if to <= from {
+		panic(fmt.Sprintf("dynamodb: register migration for %s: version %d isn't greater than %d", t, to, from))
+	}
+
+	schemas.Lock()
+	defer schemas.Unlock()
+	s := &schema{migrations: make(map[int]schemaStep)}
+	if old, ok := schemas.byType[t]; ok {
+		if _, dupe := old.migrations[from]; dupe {
+			panic(fmt.Sprintf("dynamodb: register migration for %s: duplicate migration from version %d", t, from))
+		}
+		s.current = old.current
+		for v, step := range old.migrations {
+			s.migrations[v] = step
+		}
+	}
+	s.migrations[from] = schemaStep{to: to, fn: fn}
+	if to > s.current {
+		s.current = to
+	}
+	schemas.byType[t] = s
+}
+
+func lookupSchema(rt reflect.Type) *schema {
+	if rt == nil {
+		return nil
+	}
+	schemas.RLock()
+	defer schemas.RUnlock()
+	return schemas.byType[rt]
+}
+
+// stampVersion returns item with the version attribute set if v's type has migrations.
+// item may belong to the caller (such as a map returned by an ItemMarshaler), so it is copied.
+func stampVersion(v interface{}, item map[string]types.AttributeValue) map[string]types.AttributeValue {
+	s := lookupSchema(indirectType(v))
+	if s == nil || item == nil {
+		return item
+	}
+	stamped := make(map[string]types.AttributeValue, len(item)+1)
+	for k, av := range item {
+		stamped[k] = av
+	}
+	stamped[VersionAttribute] = &types.AttributeValueMemberN{Value: strconv.Itoa(s.current)}
+	return stamped
+}
+
+// migrate upgrades item to the current version of out's type.
+// The original item is never modified.
+// Items of a newer version than known are returned as-is.
+func migrate(item map[string]types.AttributeValue, out interface{}) (map[string]types.AttributeValue, error) {
+	rt := indirectType(out)
+	s := lookupSchema(rt)
+	if s == nil {
+		return item, nil
+	}
+
+	version := 0
+	if av, ok := item[VersionAttribute]; ok {
+		avN, ok := av.(*types.AttributeValueMemberN)
+		if !ok {
+			return nil, fmt.Errorf("dynamodb: migrate %s: version attribute is %s, not number", rt, avTypeName(av))
+		}
+		var err error
+		if version, err = strconv.Atoi(avN.Value); err != nil {
+			return nil, fmt.Errorf("dynamodb: migrate %s: %w", rt, err)
+		}
+	}
+	if version >= s.current {
+		return item, nil
+	}
+
+	migrated := make(map[string]types.AttributeValue, len(item))
+	for k, v := range item {
+		migrated[k] = v
+	}
+	for version < s.current {
+		step, ok := s.migrations[version]
+		if !ok {
+			return nil, fmt.Errorf("dynamodb: migrate %s: no migration from version %d", rt, version)
+		}
+		var err error
+		if migrated, err = step.fn(migrated); err != nil {
+			return nil, fmt.Errorf("dynamodb: migrate %s from version %d to %d: %w", rt, version, step.to, err)
+		}
+		if migrated == nil {
+			return nil, fmt.Errorf("dynamodb: migrate %s from version %d to %d: migration returned a nil item", rt, version, step.to)
+		}
+		version = step.to
+	}
+	migrated[VersionAttribute] = &types.AttributeValueMemberN{Value: strconv.Itoa(version)}
+	return migrated, nil
+}
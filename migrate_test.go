@@ -0,0 +1,202 @@
+package fuel
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/go-cmp/cmp"
+)
+
+// migratedUser went through these schemas:
+//
+//	0: {"name": "Alice Smith"}
+//	1: {"first": "Alice", "last": "Smith"}
+//	2: {"First": "Alice", "Last": "Smith"}
+type migratedUser struct {
+	First string
+	Last  string
+}
+
+type brokenMigration struct {
+	A string
+}
+
+type nilMigration struct {
+	A string
+}
+
+// cachedItem marshals itself as a map it keeps
+type cachedItem struct {
+	item map[string]types.AttributeValue
+}
+
+func (c *cachedItem) MarshalDynamoDBItem() (map[string]types.AttributeValue, error) {
+	return c.item, nil
+}
+
+func init() {
+	RegisterMigration(reflect.TypeOf(migratedUser{}), 0, 1, func(item map[string]types.AttributeValue) (map[string]types.AttributeValue, error) {
+		var name string
+		if err := Unmarshal(item["name"], &name); err != nil {
+			return nil, err
+		}
+		first, last := name, ""
+		for i, r := range name {
+			if r == ' ' {
+				first, last = name[:i], name[i+1:]
+				break
+			}
+		}
+		delete(item, "name")
+		item["first"] = &types.AttributeValueMemberS{Value: first}
+		item["last"] = &types.AttributeValueMemberS{Value: last}
+		return item, nil
+	})
+	RegisterMigration(reflect.TypeOf(migratedUser{}), 1, 2, func(item map[string]types.AttributeValue) (map[string]types.AttributeValue, error) {
+		item["First"], item["Last"] = item["first"], item["last"]
+		delete(item, "first")
+		delete(item, "last")
+		return item, nil
+	})
+
+	RegisterMigration(reflect.TypeOf(cachedItem{}), 0, 1, func(item map[string]types.AttributeValue) (map[string]types.AttributeValue, error) {
+		return item, nil
+	})
+
+	RegisterMigration(reflect.TypeOf(nilMigration{}), 0, 1, func(item map[string]types.AttributeValue) (map[string]types.AttributeValue, error) {
+		return nil, nil
+	})
+
+	RegisterMigration(reflect.TypeOf(brokenMigration{}), 1, 2, func(item map[string]types.AttributeValue) (map[string]types.AttributeValue, error) {
+		return nil, errors.New("broken")
+	})
+}
+
+func TestMigrateUnmarshal(t *testing.T) {
+	want := migratedUser{First: "Alice", Last: "Smith"}
+	tests := []map[string]types.AttributeValue{
+		{
+			"name": &types.AttributeValueMemberS{Value: "Alice Smith"},
+		},
+		{
+			VersionAttribute: &types.AttributeValueMemberN{Value: "1"},
+			"first":          &types.AttributeValueMemberS{Value: "Alice"},
+			"last":           &types.AttributeValueMemberS{Value: "Smith"},
+		},
+		{
+			VersionAttribute: &types.AttributeValueMemberN{Value: "2"},
+			"First":          &types.AttributeValueMemberS{Value: "Alice"},
+			"Last":           &types.AttributeValueMemberS{Value: "Smith"},
+		},
+	}
+
+	for i, item := range tests {
+		orig := make(map[string]types.AttributeValue, len(item))
+		for k, v := range item {
+			orig[k] = v
+		}
+
+		var got migratedUser
+		if err := UnmarshalItem(item, &got); err != nil {
+			t.Errorf("version %d: unexpected error: %v", i, err)
+			continue
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("version %d: missmatch (-want, +got):\n%s", i, diff)
+		}
+		if diff := cmp.Diff(orig, item); diff != "" {
+			t.Errorf("version %d: item was modified (-want, +got):\n%s", i, diff)
+		}
+	}
+
+	var list []*migratedUser
+	if err := UnmarshalItems(tests, &list); err != nil {
+		t.Fatal(err)
+	}
+	if err := UnmarshalAppend(tests[0], &list); err != nil {
+		t.Fatal(err)
+	}
+	for _, got := range list {
+		if diff := cmp.Diff(want, *got); diff != "" {
+			t.Errorf("list: missmatch (-want, +got):\n%s", diff)
+		}
+	}
+}
+
+func TestMigrateMarshal(t *testing.T) {
+	item, err := MarshalItem(&migratedUser{First: "Alice", Last: "Smith"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]types.AttributeValue{
+		VersionAttribute: &types.AttributeValueMemberN{Value: "2"},
+		"First":          &types.AttributeValueMemberS{Value: "Alice"},
+		"Last":           &types.AttributeValueMemberS{Value: "Smith"},
+	}
+	if diff := cmp.Diff(want, item); diff != "" {
+		t.Errorf("missmatch (-want, +got):\n%s", diff)
+	}
+}
+
+func TestMigrateMarshalCopies(t *testing.T) {
+	own := map[string]types.AttributeValue{"A": &types.AttributeValueMemberS{Value: "a"}}
+	item, err := MarshalItem(&cachedItem{item: own})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := item[VersionAttribute]; !ok {
+		t.Errorf("version missing from %v", item)
+	}
+	if _, ok := own[VersionAttribute]; ok {
+		t.Error("the marshaler's map was modified")
+	}
+}
+
+func TestMigrateErrors(t *testing.T) {
+	var out brokenMigration
+	// no migration from version 0
+	if err := UnmarshalItem(map[string]types.AttributeValue{}, &out); err == nil {
+		t.Error("missing migration: expected error, got nil")
+	}
+	item := map[string]types.AttributeValue{
+		VersionAttribute: &types.AttributeValueMemberN{Value: "1"},
+	}
+	if err := UnmarshalItem(item, &out); err == nil {
+		t.Error("failing migration: expected error, got nil")
+	}
+	item[VersionAttribute] = &types.AttributeValueMemberN{Value: "3"}
+	if err := UnmarshalItem(item, &out); err != nil {
+		t.Errorf("newer version: unexpected error: %v", err)
+	}
+	if err := UnmarshalItem(map[string]types.AttributeValue{}, &nilMigration{}); err == nil {
+		t.Error("nil item from migration: expected error, got nil")
+	}
+}
+
+func TestMigrateRegisterConcurrently(t *testing.T) {
+	type growing struct {
+		A string
+	}
+	rt := reflect.TypeOf(growing{})
+	keep := func(item map[string]types.AttributeValue) (map[string]types.AttributeValue, error) {
+		return item, nil
+	}
+	RegisterMigration(rt, 0, 1, keep)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for v := 1; v < 50; v++ {
+			RegisterMigration(rt, v, v+1, keep)
+		}
+	}()
+	for i := 0; i < 50; i++ {
+		var out growing
+		if err := UnmarshalItem(map[string]types.AttributeValue{}, &out); err != nil {
+			t.Fatal(err)
+		}
+	}
+	<-done
+}
@@ -0,0 +1,93 @@
+package fuel
+
+import (
+	"strings"
+	"unicode"
+)
+
+// NamingStrategy derives attribute names for struct fields that don't have a name in their tag.
+// Explicitly tagged names are always used as-is.
+type NamingStrategy struct {
+	convert func(fieldName string) string
+}
+
+var (
+	// IdentityNames uses the Go field name. This is the default.
+	IdentityNames = &NamingStrategy{convert: func(name string) string { return name }}
+	// CamelCaseNames converts field names like UserID into userId.
+	CamelCaseNames = &NamingStrategy{convert: camelCase}
+	// SnakeCaseNames converts field names like UserID into user_id.
+	SnakeCaseNames = &NamingStrategy{convert: func(name string) string { return joinWords(name, "_") }}
+	// KebabCaseNames converts field names like UserID into user-id.
+	KebabCaseNames = &NamingStrategy{convert: func(name string) string { return joinWords(name, "-") }}
+)
+
+// NamingFunc returns a NamingStrategy that names fields with fn.
+// Field layouts are cached per strategy, so create it once and reuse it.
+func NamingFunc(fn func(fieldName string) string) *NamingStrategy {
+	return &NamingStrategy{convert: fn}
+}
+
+// NameFields sets the naming strategy for untagged struct fields.
+// Pass it to both NewEncoder and NewDecoder.
+func NameFields(strategy *NamingStrategy) CodecOption {
+	return func(opts *fieldOptions) {
+		opts.naming = strategy
+	}
+}
+
+// splitWords splits a Go identifier into words, keeping acronyms together:
+// "HTTPServerID2" becomes "HTTP", "Server", "ID2".
+func splitWords(name string) []string {
+	runes := []rune(name)
+	var words []string
+	start := 0
+	for i := 1; i < len(runes); i++ {
+		prev, cur := runes[i-1], runes[i]
+		switch {
+		case cur == '_' || cur == '-':
+			if start < i {
+				words = append(words, string(runes[start:i]))
+			}
+			start = i + 1
+			continue
+		case start == i:
+			continue
+		case unicode.IsUpper(cur) && (unicode.IsLower(prev) || unicode.IsDigit(prev)):
+			// fooBar, foo2Bar
+		case unicode.IsUpper(cur) && unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1]):
+			// end of an acronym: HTTPServer
+		default:
+			continue
+		}
+		words = append(words, string(runes[start:i]))
+		start = i
+	}
+	if start < len(runes) {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
+
+func joinWords(name, sep string) string {
+	words := splitWords(name)
+	for i, w := range words {
+		words[i] = strings.ToLower(w)
+	}
+	return strings.Join(words, sep)
+}
+
+func camelCase(name string) string {
+	words := splitWords(name)
+	var sb strings.Builder
+	for i, w := range words {
+		w = strings.ToLower(w)
+		if i > 0 {
+			r := []rune(w)
+			r[0] = unicode.ToUpper(r[0])
+			w = string(r)
+		}
+		sb.WriteString(w)
+	}
+	return sb.String()
+}
@@ -0,0 +1,97 @@
+package fuel
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNamingStrategies(t *testing.T) {
+	tests := []struct {
+		in    string
+		camel string
+		snake string
+		kebab string
+	}{
+		{in: "Name", camel: "name", snake: "name", kebab: "name"},
+		{in: "UserID", camel: "userId", snake: "user_id", kebab: "user-id"},
+		{in: "HTTPServer", camel: "httpServer", snake: "http_server", kebab: "http-server"},
+		{in: "ID", camel: "id", snake: "id", kebab: "id"},
+		{in: "Address2Line", camel: "address2Line", snake: "address2_line", kebab: "address2-line"},
+		{in: "Already_Snake", camel: "alreadySnake", snake: "already_snake", kebab: "already-snake"},
+	}
+
+	for _, tc := range tests {
+		if got := CamelCaseNames.convert(tc.in); got != tc.camel {
+			t.Errorf("camel(%s): want %s, got %s", tc.in, tc.camel, got)
+		}
+		if got := SnakeCaseNames.convert(tc.in); got != tc.snake {
+			t.Errorf("snake(%s): want %s, got %s", tc.in, tc.snake, got)
+		}
+		if got := KebabCaseNames.convert(tc.in); got != tc.kebab {
+			t.Errorf("kebab(%s): want %s, got %s", tc.in, tc.kebab, got)
+		}
+	}
+}
+
+type namingItem struct {
+	UserID    string
+	CreatedAt int
+	Explicit  string `dynamodb:"EXPLICIT"`
+	Flagged   bool   `dynamodb:",omitempty"`
+	ExportedEmbedded
+}
+
+func TestNameFields(t *testing.T) {
+	in := namingItem{
+		UserID:           "u",
+		CreatedAt:        1,
+		Explicit:         "x",
+		Flagged:          true,
+		ExportedEmbedded: ExportedEmbedded{Embedded: true},
+	}
+	want := map[string]types.AttributeValue{
+		"user_id":    &types.AttributeValueMemberS{Value: "u"},
+		"created_at": &types.AttributeValueMemberN{Value: "1"},
+		"EXPLICIT":   &types.AttributeValueMemberS{Value: "x"},
+		"flagged":    &types.AttributeValueMemberBOOL{Value: true},
+		"embedded":   &types.AttributeValueMemberBOOL{Value: true},
+	}
+
+	opt := NameFields(SnakeCaseNames)
+	item, err := NewEncoder(opt).MarshalItem(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want, item); diff != "" {
+		t.Errorf("encode missmatch (-want, +got):\n%s", diff)
+	}
+
+	var out namingItem
+	if err := NewDecoder(opt).UnmarshalItem(item, &out); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(in, out); diff != "" {
+		t.Errorf("decode missmatch (-want, +got):\n%s", diff)
+	}
+
+	// the default encoder is unaffected
+	item, err = MarshalItem(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := item["UserID"]; !ok {
+		t.Errorf("default encoder used naming strategy: %v", item)
+	}
+
+	upper := NameFields(NamingFunc(strings.ToUpper))
+	item, err = NewEncoder(upper).MarshalItem(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := item["CREATEDAT"]; !ok {
+		t.Errorf("custom naming func not applied: %v", item)
+	}
+}
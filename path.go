@@ -0,0 +1,89 @@
+package fuel
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// pathElem is one step of a document path: either an attribute name or a list index
+type pathElem struct {
+	name  string
+	index int
+}
+
+func (pe pathElem) isIndex() bool {
+	return pe.name == ""
+}
+
+// parsePath splits a document path such as "orders[2].lines[0].sku" into its elements,
+// following the syntax DynamoDB uses in expressions. A backslash makes the next character
+// part of the name, so that names containing '.', '[', ']' or '\' can be written.
+func parsePath(path string) ([]pathElem, error) {
+	if path == "" {
+		return nil, fmt.Errorf("dynamodb: invalid path: empty")
+	}
+
+	var elems []pathElem
+	for i := 0; ; {
+		// attribute name, up to the next unescaped '.' or '['
+		var name strings.Builder
+		for ; i < len(path) && path[i] != '.' && path[i] != '['; i++ {
+			switch path[i] {
+			case ']':
+				return nil, fmt.Errorf("dynamodb: invalid path %q: unexpected ]", path)
+			case '\\':
+				if i++; i == len(path) {
+					return nil, fmt.Errorf("dynamodb: invalid path %q: trailing backslash", path)
+				}
+			}
+			name.WriteByte(path[i])
+		}
+		if name.Len() == 0 {
+			return nil, fmt.Errorf("dynamodb: invalid path %q: empty attribute name", path)
+		}
+		elems = append(elems, pathElem{name: name.String()})
+
+		for i < len(path) && path[i] == '[' {
+			end := strings.IndexByte(path[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("dynamodb: invalid path %q: malformed index", path)
+			}
+			idx, err := strconv.Atoi(path[i+1 : i+end])
+			if err != nil || idx < 0 {
+				return nil, fmt.Errorf("dynamodb: invalid path %q: bad index %q", path, path[i+1:i+end])
+			}
+			elems = append(elems, pathElem{index: idx})
+			i += end + 1
+		}
+		if i == len(path) {
+			return elems, nil
+		}
+		if path[i] != '.' {
+			return nil, fmt.Errorf("dynamodb: invalid path %q: malformed index", path)
+		}
+		i++
+	}
+}
+
+// formatPath writes elems in document path syntax, such as "orders[2].lines[0].sku".
+// A backslash is put before any '.', '[', ']' or '\' in a name, so parsePath reads it back as it is.
+func formatPath(elems []pathElem) string {
+	var b strings.Builder
+	for i, elem := range elems {
+		if elem.isIndex() {
+			b.WriteString("[" + strconv.Itoa(elem.index) + "]")
+			continue
+		}
+		if i > 0 {
+			b.WriteByte('.')
+		}
+		for j := 0; j < len(elem.name); j++ {
+			if c := elem.name[j]; c == '.' || c == '[' || c == ']' || c == '\\' {
+				b.WriteByte('\\')
+			}
+			b.WriteByte(elem.name[j])
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,71 @@
+package fuel
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParsePath(t *testing.T) {
+	tests := []struct {
+		path string
+		want []pathElem
+		err  bool
+	}{
+		{
+			path: "A",
+			want: []pathElem{{name: "A"}},
+		},
+		{
+			path: "orders[2].lines[0].sku",
+			want: []pathElem{{name: "orders"}, {index: 2}, {name: "lines"}, {index: 0}, {name: "sku"}},
+		},
+		{
+			path: "matrix[1][3]",
+			want: []pathElem{{name: "matrix"}, {index: 1}, {index: 3}},
+		},
+		{
+			path: `a\.b.c\[0\]\\[1]`,
+			want: []pathElem{{name: "a.b"}, {name: `c[0]\`}, {index: 1}},
+		},
+		{path: "", err: true},
+		{path: `a\`, err: true},
+		{path: "a.", err: true},
+		{path: "a..b", err: true},
+		{path: "[0]", err: true},
+		{path: "a[x]", err: true},
+		{path: "a[1", err: true},
+		{path: "a[-1]", err: true},
+		{path: "a]b", err: true},
+		{path: "a[0]b", err: true},
+	}
+
+	for _, tc := range tests {
+		got, err := parsePath(tc.path)
+		if tc.err {
+			if err == nil {
+				t.Errorf("%q: expected error, got %v", tc.path, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tc.path, err)
+			continue
+		}
+		if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(pathElem{})); diff != "" {
+			t.Errorf("%q: missmatch (-want, +got):\n%s", tc.path, diff)
+		}
+	}
+}
+
+func TestFormatPath(t *testing.T) {
+	for _, path := range []string{"A", "orders[2].lines[0].sku", `a\.b.c\[0\]\\[1]`} {
+		elems, err := parsePath(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := formatPath(elems); got != path {
+			t.Errorf("%q: formatted as %q", path, got)
+		}
+	}
+}
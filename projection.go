@@ -0,0 +1,125 @@
+package fuel
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Projection is a projection expression: the attributes and document paths to fetch.
+// The zero Projection is empty and renders as "", which fetches whole items.
+type Projection struct {
+	paths [][]pathElem
+	// type of Project and ProjectNested, described with the Placeholders' Encoder when rendering
+	typ    reflect.Type
+	nested bool
+	err    error
+}
+
+// ProjectPaths is the projection of the given attributes or document paths, such as "orders[0].sku".
+// Items of types with migrations are decoded as version 0 unless VersionAttribute is among them.
+func ProjectPaths(paths ...string) Projection {
+	var pr Projection
+	for _, path := range paths {
+		elems, err := parsePath(path)
+		if err != nil {
+			return Projection{err: err}
+		}
+		pr.paths = append(pr.paths, elems)
+	}
+	return pr
+}
+
+// Project is the projection of the attributes that values of v's type are decoded from,
+// where v is a struct or a pointer to one. The field layout is the one of the Encoder
+// the projection is rendered with (see Placeholders), such as a DB's.
+// For types with migrations, it includes VersionAttribute, so that current items aren't migrated again.
+func Project(v interface{}) Projection {
+	return project(v, false)
+}
+
+// ProjectNested is like Project, but for fields holding nested structs it only fetches
+// the document paths those structs are decoded from, instead of the whole map.
+func ProjectNested(v interface{}) Projection {
+	return project(v, true)
+}
+
+func project(v interface{}, nested bool) Projection {
+	rt := reflect.TypeOf(v)
+	if rt == nil {
+		return Projection{err: fmt.Errorf("dynamodb: projection: nil value")}
+	}
+	return Projection{typ: rt, nested: nested}
+}
+
+// Projection is the projection of the attributes described by s, including aliases.
+func (s *Schema) Projection() Projection {
+	var pr Projection
+	s.project(nil, false, &pr)
+	return pr
+}
+
+// NestedProjection is like Projection, but for attributes holding nested structs it only
+// includes the document paths those structs are decoded from, instead of the whole map.
+func (s *Schema) NestedProjection() Projection {
+	var pr Projection
+	s.project(nil, true, &pr)
+	return pr
+}
+
+var (
+	nilIum  ItemUnmarshaler
+	iumType = reflect.TypeOf(&nilIum).Elem()
+)
+
+func (s *Schema) project(prefix []pathElem, nested bool, pr *Projection) {
+	for _, attr := range s.Attributes {
+		for _, name := range append([]string{attr.Name}, attr.Aliases...) {
+			path := append(append([]pathElem(nil), prefix...), pathElem{name: name})
+			if nested && projectsFields(attr) {
+				attr.Nested.project(path, nested, pr)
+				continue
+			}
+			pr.paths = append(pr.paths, path)
+		}
+	}
+}
+
+// projectsFields reports whether attr holds a struct whose fields are decoded one by one,
+// so that its attributes can be projected separately
+func projectsFields(attr Attribute) bool {
+	if attr.Nested == nil || len(attr.Nested.Attributes) == 0 || attr.AttributeType != MapType {
+		return false
+	}
+	t := derefType(attr.GoType)
+	if t.Kind() != reflect.Struct {
+		// a list or map of structs
+		return false
+	}
+	// types that decode themselves may need any attribute
+	pt := reflect.PtrTo(t)
+	return !pt.Implements(iumType) && !pt.Implements(umType) && !pt.Implements(tumType)
+}
+
+// Render returns the projection expression, adding the names it uses to p.
+func (pr Projection) Render(p *Placeholders) (string, error) {
+	if pr.err != nil {
+		return "", pr.err
+	}
+	if pr.typ != nil {
+		schema, err := p.encoder().Describe(pr.typ)
+		if err != nil {
+			return "", err
+		}
+		if pr.nested {
+			pr = schema.NestedProjection()
+		} else {
+			pr = schema.Projection()
+		}
+	}
+	exprs := make([]string, 0, len(pr.paths))
+	for _, path := range pr.paths {
+		exprs = append(exprs, p.pathElems(path))
+	}
+	return strings.Join(exprs, ", "), nil
+}
@@ -0,0 +1,98 @@
+package fuel
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type projectionAddress struct {
+	City string `dynamodb:"city"`
+	Zip  string `dynamodb:"zip,alias=postcode"`
+}
+
+type projectionItem struct {
+	ID      string `dynamodb:"id,alias=Id"`
+	Home    projectionAddress
+	Work    *projectionAddress
+	Lines   []projectionAddress
+	Updated time.Time
+	Skipped string `dynamodb:"-"`
+	*ExportedEmbedded
+}
+
+func TestProjectionRender(t *testing.T) {
+	tests := []struct {
+		name       string
+		projection Projection
+		expr       string
+		names      map[string]string
+		shouldFail bool
+	}{
+		{
+			name:       "zero",
+			projection: Projection{},
+			expr:       "",
+		},
+		{
+			name:       "paths",
+			projection: ProjectPaths("id", "orders[0].sku", "orders[1].sku"),
+			expr:       "id, orders[0].sku, orders[1].sku",
+		},
+		{
+			name:       "struct",
+			projection: Project(&projectionItem{}),
+			expr:       "id, Id, Home, #n0, #n1, Updated, Embedded",
+			names:      map[string]string{"#n0": "Work", "#n1": "Lines"},
+		},
+		{
+			name:       "nested",
+			projection: ProjectNested(projectionItem{}),
+			expr:       "id, Id, Home.city, Home.zip, Home.postcode, #n0.city, #n0.zip, #n0.postcode, #n1, Updated, Embedded",
+			names:      map[string]string{"#n0": "Work", "#n1": "Lines"},
+		},
+		{
+			name:       "migrated",
+			projection: Project(migratedUser{}),
+			expr:       "#n0, #n1, #n2",
+			names:      map[string]string{"#n0": "First", "#n1": "Last", "#n2": VersionAttribute},
+		},
+		{
+			name:       "invalid path",
+			projection: ProjectPaths("id", "a..b"),
+			shouldFail: true,
+		},
+		{
+			name:       "not a struct",
+			projection: Project(1),
+			shouldFail: true,
+		},
+		{
+			name:       "nil",
+			projection: Project(nil),
+			shouldFail: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p Placeholders
+			expr, err := tt.projection.Render(&p)
+			if tt.shouldFail {
+				if err == nil {
+					t.Fatalf("expected error, got %q", expr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if expr != tt.expr {
+				t.Errorf("expression missmatch: want %q, got %q", tt.expr, expr)
+			}
+			if diff := cmp.Diff(tt.names, p.Names); diff != "" {
+				t.Errorf("names missmatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
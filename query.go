@@ -0,0 +1,151 @@
+package fuel
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// SortOrder is the order of query results by sort key.
+type SortOrder int
+
+const (
+	// Ascending sorts query results by ascending sort key. It is the default.
+	Ascending SortOrder = iota
+	// Descending sorts query results by descending sort key.
+	Descending
+)
+
+// Query is a request for the items of a partition, following LastEvaluatedKey across pages.
+// Build one with Table.Query and its chainable methods, then run it with All, One or Iter.
+type Query struct {
+	table      *Table
+	key        KeyCondition
+	filter     Condition
+	index      string
+	limit      int
+	order      SortOrder
+	consistent bool
+	projection Projection
+}
+
+// Query returns a query for the items whose partition key name equals v.
+func (t *Table) Query(name string, v interface{}) *Query {
+	return &Query{table: t, key: Key(name, v)}
+}
+
+// Range restricts the query to the items whose sort key name satisfies c.
+func (q *Query) Range(name string, c SortKeyCondition) *Query {
+	q.key = q.key.Range(name, c)
+	return q
+}
+
+// Filter drops the items that don't satisfy cond, after they are read.
+// Filters can't use key attributes. Calling Filter again adds to the filter.
+func (q *Query) Filter(cond Condition) *Query {
+	q.filter = q.filter.And(cond)
+	return q
+}
+
+// Index makes the query read the secondary index name instead of the table.
+func (q *Query) Index(name string) *Query {
+	q.index = name
+	return q
+}
+
+// Limit makes the query return at most n items in all. 0 means no limit.
+func (q *Query) Limit(n int) *Query {
+	q.limit = n
+	return q
+}
+
+// Order sets the order of the results by sort key.
+func (q *Query) Order(order SortOrder) *Query {
+	q.order = order
+	return q
+}
+
+// Consistent makes the query strongly consistent. Global secondary indexes don't support it.
+func (q *Query) Consistent() *Query {
+	q.consistent = true
+	return q
+}
+
+// Project makes the query fetch only the attributes of pr.
+func (q *Query) Project(pr Projection) *Query {
+	q.projection = pr
+	return q
+}
+
+// All decodes every result, appending them to the slice pointed to by out with UnmarshalItems.
+func (q *Query) All(ctx context.Context, out interface{}) error {
+	return q.Iter().all(ctx, out)
+}
+
+// One decodes the first result into out with UnmarshalItem.
+// If there are no results, it returns ErrNotFound.
+// Without a filter, it reads a single item; with one, it reads pages until an item matches.
+func (q *Query) One(ctx context.Context, out interface{}) error {
+	it := q.Iter()
+	if q.filter.IsZero() {
+		it.limit = 1
+	}
+	if it.Next(ctx, out) {
+		return nil
+	}
+	if err := it.Err(); err != nil {
+		return err
+	}
+	return ErrNotFound
+}
+
+// Iter returns an iterator over the results, which fetches one page at a time.
+func (q *Query) Iter() *Iter {
+	var in *dynamodb.QueryInput
+	fetch := func(ctx context.Context, startKey map[string]types.AttributeValue, limit int) (page, error) {
+		if in == nil {
+			var err error
+			if in, err = q.input(); err != nil {
+				return page{}, err
+			}
+		}
+		in.ExclusiveStartKey = startKey
+		in.Limit = nil
+		if limit > 0 {
+			in.Limit = aws.Int32(int32(limit))
+		}
+		out, err := q.table.db.client.Query(ctx, in)
+		if err != nil {
+			return page{}, err
+		}
+		return page{items: out.Items, lastKey: out.LastEvaluatedKey}, nil
+	}
+	return &Iter{fetch: fetch, dec: q.table.db.dec, limit: q.limit}
+}
+
+func (q *Query) input() (*dynamodb.QueryInput, error) {
+	p := q.table.placeholders()
+	in, err := q.key.queryInput(q.table.name, q.filter, p)
+	if err != nil {
+		return nil, err
+	}
+	proj, err := q.projection.Render(p)
+	if err != nil {
+		return nil, err
+	}
+	if proj != "" {
+		in.ProjectionExpression = aws.String(proj)
+	}
+	if q.index != "" {
+		in.IndexName = aws.String(q.index)
+	}
+	if q.consistent {
+		in.ConsistentRead = aws.Bool(true)
+	}
+	in.ScanIndexForward = aws.Bool(q.order != Descending)
+	in.ExpressionAttributeNames = p.Names
+	in.ExpressionAttributeValues = p.Values
+	return in, nil
+}
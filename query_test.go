@@ -0,0 +1,187 @@
+package fuel
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/google/go-cmp/cmp"
+)
+
+func newQueryTable(t *testing.T, n, pageSize int) (*Table, *fakeClient) {
+	t.Helper()
+	client := newFakeClient()
+	client.pageSize = pageSize
+	table := NewDB(client).Table("items")
+	for i := 0; i < n; i++ {
+		if err := table.Put(context.Background(), dbItem{ID: strconv.Itoa(i), Count: i}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return table, client
+}
+
+func TestQueryAll(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name     string
+		query    func(*Table) *Query
+		want     []int
+		requests int
+	}{
+		{
+			name:     "all pages",
+			query:    func(t *Table) *Query { return t.Query("pk", "a") },
+			want:     []int{0, 1, 2, 3, 4, 5, 6},
+			requests: 3,
+		},
+		{
+			name:     "limit",
+			query:    func(t *Table) *Query { return t.Query("pk", "a").Limit(4) },
+			want:     []int{0, 1, 2, 3},
+			requests: 2,
+		},
+		{
+			name:     "descending",
+			query:    func(t *Table) *Query { return t.Query("pk", "a").Order(Descending).Limit(2) },
+			want:     []int{6, 5},
+			requests: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, client := newQueryTable(t, 7, 3)
+			client.requests = 0
+
+			var got []dbItem
+			if err := tt.query(table).All(ctx, &got); err != nil {
+				t.Fatal(err)
+			}
+			var counts []int
+			for _, item := range got {
+				counts = append(counts, item.Count)
+			}
+			if diff := cmp.Diff(tt.want, counts); diff != "" {
+				t.Errorf("missmatch (-want, +got):\n%s", diff)
+			}
+			if client.requests != tt.requests {
+				t.Errorf("requests missmatch: want %d, got %d", tt.requests, client.requests)
+			}
+		})
+	}
+}
+
+func TestQueryInput(t *testing.T) {
+	table, client := newQueryTable(t, 1, 0)
+	q := table.Query("pk", "user#1").
+		Range("sk", SortBeginsWith("ORDER#")).
+		Filter(Equal(Name("Status"), "open")).
+		Filter(AttributeExists("Total")).
+		Index("GSI1").
+		Consistent().
+		Project(ProjectPaths("sk", "Total")).
+		Order(Descending).
+		Limit(10)
+	var got dbItem
+	if err := q.One(context.Background(), &got); err != nil {
+		t.Fatal(err)
+	}
+
+	in := client.last.(*dynamodb.QueryInput)
+	want := map[string]string{
+		"table":      "items",
+		"key":        "pk = :v0 AND begins_with(sk, :v1)",
+		"filter":     "#n0 = :v2 AND attribute_exists(#n1)",
+		"projection": "sk, #n1",
+		"index":      "GSI1",
+		"limit":      "10",
+		"forward":    "false",
+		"consistent": "true",
+	}
+	gotInput := map[string]string{
+		"table":      aws.ToString(in.TableName),
+		"key":        aws.ToString(in.KeyConditionExpression),
+		"filter":     aws.ToString(in.FilterExpression),
+		"projection": aws.ToString(in.ProjectionExpression),
+		"index":      aws.ToString(in.IndexName),
+		"limit":      strconv.Itoa(int(aws.ToInt32(in.Limit))),
+		"forward":    strconv.FormatBool(aws.ToBool(in.ScanIndexForward)),
+		"consistent": strconv.FormatBool(aws.ToBool(in.ConsistentRead)),
+	}
+	if diff := cmp.Diff(want, gotInput); diff != "" {
+		t.Errorf("missmatch (-want, +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(map[string]string{"#n0": "Status", "#n1": "Total"}, in.ExpressionAttributeNames); diff != "" {
+		t.Errorf("names missmatch (-want, +got):\n%s", diff)
+	}
+}
+
+func TestQueryOne(t *testing.T) {
+	ctx := context.Background()
+	table, client := newQueryTable(t, 3, 0)
+	var got dbItem
+	if err := table.Query("pk", "a").Order(Descending).One(ctx, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Count != 2 {
+		t.Errorf("missmatch: want 2, got %d", got.Count)
+	}
+	if in := client.last.(*dynamodb.QueryInput); aws.ToInt32(in.Limit) != 1 {
+		t.Errorf("without filter: want Limit 1, got %v", in.Limit)
+	}
+	if err := table.Query("pk", "a").Filter(Equal(Name("Count"), 1)).One(ctx, &got); err != nil {
+		t.Fatal(err)
+	}
+	if in := client.last.(*dynamodb.QueryInput); in.Limit != nil {
+		t.Errorf("with filter: want no Limit, got %d", *in.Limit)
+	}
+
+	empty, _ := newQueryTable(t, 0, 1)
+	if err := empty.Query("pk", "a").One(ctx, &got); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+	if err := table.Query("pk", "a").Filter(Equal(Name("pk"), 1)).One(ctx, &got); err == nil {
+		t.Error("filter on key: expected error, got nil")
+	}
+}
+
+func TestQueryIter(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	table, client := newQueryTable(t, 5, 2)
+	client.requests = 0
+
+	it := table.Query("pk", "a").Iter()
+	var item dbItem
+	var counts []int
+	for it.Next(ctx, &item) {
+		counts = append(counts, item.Count)
+		if item.Count == 2 {
+			// pages are fetched as needed
+			if client.requests != 2 {
+				t.Errorf("requests missmatch: want 2, got %d", client.requests)
+			}
+		}
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]int{0, 1, 2, 3, 4}, counts); diff != "" {
+		t.Errorf("missmatch (-want, +got):\n%s", diff)
+	}
+
+	it = table.Query("pk", "a").Iter()
+	if !it.Next(ctx, &item) || !it.Next(ctx, &item) {
+		t.Fatal(it.Err())
+	}
+	cancel()
+	if it.Next(ctx, &item) {
+		t.Error("expected the iteration to stop after cancel")
+	}
+	if !errors.Is(it.Err(), context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", it.Err())
+	}
+}
@@ -0,0 +1,346 @@
+package fuel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// Scan is a request for every item of a table or index, optionally split into segments
+// that are scanned in parallel. Build one with Table.Scan and its chainable methods,
+// then run it with Each.
+type Scan struct {
+	table      *Table
+	filter     Condition
+	index      string
+	consistent bool
+	projection Projection
+
+	segments        int
+	workers         int
+	continueOnError bool
+	resume          *Checkpoint
+	onCheckpoint    func(Checkpoint)
+}
+
+// Checkpoint is the progress of a scan, as reported after every page. Pass it to Scan.Resume
+// to continue an interrupted scan where it stopped.
+type Checkpoint struct {
+	// Segments holds the progress of each segment
+	Segments []SegmentCheckpoint
+}
+
+// SegmentCheckpoint is the progress of one segment of a scan.
+type SegmentCheckpoint struct {
+	// LastKey is the LastEvaluatedKey of the last page whose items were all processed,
+	// or nil if the segment hasn't processed a page yet
+	LastKey map[string]types.AttributeValue
+	// Done reports whether the whole segment was processed
+	Done bool
+}
+
+// Done reports whether every segment was processed.
+func (cp Checkpoint) Done() bool {
+	for _, seg := range cp.Segments {
+		if !seg.Done {
+			return false
+		}
+	}
+	return len(cp.Segments) > 0
+}
+
+func (cp Checkpoint) clone() Checkpoint {
+	return Checkpoint{Segments: append([]SegmentCheckpoint(nil), cp.Segments...)}
+}
+
+// SegmentError is the error that stopped a segment of a scan.
+type SegmentError struct {
+	Segment int
+	Err     error
+}
+
+func (e *SegmentError) Error() string {
+	return fmt.Sprintf("dynamodb: scan segment %d: %v", e.Segment, e.Err)
+}
+
+func (e *SegmentError) Unwrap() error {
+	return e.Err
+}
+
+// SegmentErrors are the errors of the segments that failed in a scan with ContinueOnError.
+type SegmentErrors []*SegmentError
+
+func (errs SegmentErrors) Error() string {
+	msgs := make([]string, 0, len(errs))
+	for _, err := range errs {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Scan returns a scan of every item of the table.
+func (t *Table) Scan() *Scan {
+	return &Scan{table: t}
+}
+
+// Filter drops the items that don't satisfy cond, after they are read.
+// Calling Filter again adds to the filter.
+func (s *Scan) Filter(cond Condition) *Scan {
+	s.filter = s.filter.And(cond)
+	return s
+}
+
+// Index makes the scan read the secondary index name instead of the table.
+func (s *Scan) Index(name string) *Scan {
+	s.index = name
+	return s
+}
+
+// Consistent makes the scan strongly consistent. Global secondary indexes don't support it.
+func (s *Scan) Consistent() *Scan {
+	s.consistent = true
+	return s
+}
+
+// Project makes the scan fetch only the attributes of pr.
+func (s *Scan) Project(pr Projection) *Scan {
+	s.projection = pr
+	return s
+}
+
+// Segments splits the scan into n segments (TotalSegments), each paginated on its own.
+// The default is 1, a sequential scan.
+func (s *Scan) Segments(n int) *Scan {
+	s.segments = n
+	return s
+}
+
+// Workers makes at most n segments be scanned at once. The default is one worker per segment.
+func (s *Scan) Workers(n int) *Scan {
+	s.workers = n
+	return s
+}
+
+// ContinueOnError makes the other segments go on when one of them fails.
+// By default, the first error cancels the whole scan.
+func (s *Scan) ContinueOnError() *Scan {
+	s.continueOnError = true
+	return s
+}
+
+// Resume continues a scan from cp, skipping the segments that are done and the pages
+// that were processed. The scan must have as many segments as cp; if Segments
+// wasn't called, it is taken from cp.
+func (s *Scan) Resume(cp Checkpoint) *Scan {
+	cp = cp.clone()
+	s.resume = &cp
+	return s
+}
+
+// OnCheckpoint calls fn with the progress of the scan after every page a segment processes.
+// Calls are never concurrent.
+func (s *Scan) OnCheckpoint(fn func(Checkpoint)) *Scan {
+	s.onCheckpoint = fn
+	return s
+}
+
+// Each scans the items and calls fn with each of them, decoded with UnmarshalItem.
+// fn is a func(*T) error or a func(T) error, where T is the type to decode items into.
+// Workers call fn concurrently, so it must be safe for concurrent use.
+//
+// If fn or a request fails, the segment stops at its last checkpoint. By default the other
+// segments are canceled too, and Each returns a *SegmentError. With ContinueOnError they
+// go on, and Each returns the SegmentErrors of all the segments that failed.
+// If ctx is canceled, Each returns its error.
+func (s *Scan) Each(ctx context.Context, fn interface{}) error {
+	call, err := scanCallback(s.table.db.dec, fn)
+	if err != nil {
+		return err
+	}
+
+	total := s.segments
+	if total == 0 && s.resume != nil {
+		total = len(s.resume.Segments)
+	}
+	if total <= 0 {
+		total = 1
+	}
+	cp := Checkpoint{Segments: make([]SegmentCheckpoint, total)}
+	if s.resume != nil {
+		if len(s.resume.Segments) != total {
+			return fmt.Errorf("dynamodb: scan: checkpoint has %d segments, scan has %d", len(s.resume.Segments), total)
+		}
+		cp = s.resume.clone()
+	}
+	in, err := s.input(total)
+	if err != nil {
+		return err
+	}
+
+	parent := ctx
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var mu sync.Mutex
+	var errs SegmentErrors
+	// update records the progress of a segment
+	update := func(segment int, lastKey map[string]types.AttributeValue) {
+		mu.Lock()
+		defer mu.Unlock()
+		cp.Segments[segment] = SegmentCheckpoint{LastKey: lastKey, Done: lastKey == nil}
+		if s.onCheckpoint != nil {
+			s.onCheckpoint(cp.clone())
+		}
+	}
+	fail := func(segment int, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		errs = append(errs, &SegmentError{Segment: segment, Err: err})
+		if !s.continueOnError {
+			cancel()
+		}
+	}
+
+	type segmentStart struct {
+		segment int
+		lastKey map[string]types.AttributeValue
+	}
+	todo := make(chan segmentStart, total)
+	for i, seg := range cp.Segments {
+		if !seg.Done {
+			todo <- segmentStart{segment: i, lastKey: seg.LastKey}
+		}
+	}
+	close(todo)
+
+	workers := s.workers
+	if workers <= 0 || workers > total {
+		workers = total
+	}
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for start := range todo {
+				if ctx.Err() != nil {
+					return
+				}
+				err := s.scanSegment(ctx, *in, start.segment, start.lastKey, call, update)
+				// segments stopped by the cancellation aren't failures
+				if err != nil && !(ctx.Err() != nil && errors.Is(err, ctx.Err())) {
+					fail(start.segment, err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		if !s.continueOnError {
+			return errs[0]
+		}
+		return errs
+	}
+	if !cp.Done() {
+		// without errors, only the parent context stops segments
+		return parent.Err()
+	}
+	return nil
+}
+
+// scanSegment scans one segment from start, calling update after every page
+func (s *Scan) scanSegment(ctx context.Context, in dynamodb.ScanInput, segment int, start map[string]types.AttributeValue,
+	call func(map[string]types.AttributeValue) error, update func(int, map[string]types.AttributeValue)) error {
+	if in.TotalSegments != nil {
+		in.Segment = aws.Int32(int32(segment))
+	}
+	in.ExclusiveStartKey = start
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		out, err := s.table.db.client.Scan(ctx, &in)
+		if err != nil {
+			return err
+		}
+		for _, item := range out.Items {
+			if err := call(item); err != nil {
+				return err
+			}
+		}
+		update(segment, out.LastEvaluatedKey)
+		if out.LastEvaluatedKey == nil {
+			return nil
+		}
+		in.ExclusiveStartKey = out.LastEvaluatedKey
+	}
+}
+
+func (s *Scan) input(total int) (*dynamodb.ScanInput, error) {
+	p := s.table.placeholders()
+	filter, err := s.filter.Render(p)
+	if err != nil {
+		return nil, err
+	}
+	proj, err := s.projection.Render(p)
+	if err != nil {
+		return nil, err
+	}
+
+	in := &dynamodb.ScanInput{
+		TableName:                 aws.String(s.table.name),
+		ExpressionAttributeNames:  p.Names,
+		ExpressionAttributeValues: p.Values,
+	}
+	if filter != "" {
+		in.FilterExpression = aws.String(filter)
+	}
+	if proj != "" {
+		in.ProjectionExpression = aws.String(proj)
+	}
+	if s.index != "" {
+		in.IndexName = aws.String(s.index)
+	}
+	if s.consistent {
+		in.ConsistentRead = aws.Bool(true)
+	}
+	if total > 1 {
+		in.TotalSegments = aws.Int32(int32(total))
+	}
+	return in, nil
+}
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// scanCallback adapts fn, a func(*T) error or func(T) error, to take items
+func scanCallback(dec *Decoder, fn interface{}) (func(map[string]types.AttributeValue) error, error) {
+	fv := reflect.ValueOf(fn)
+	if fv.Kind() != reflect.Func || fv.IsNil() || fv.Type().NumIn() != 1 || fv.Type().NumOut() != 1 || fv.Type().Out(0) != errorType {
+		return nil, fmt.Errorf("dynamodb: scan: callback must be a func(*T) error or func(T) error, got %T", fn)
+	}
+	in := fv.Type().In(0)
+	ptr := in.Kind() == reflect.Ptr
+	elem := in
+	if ptr {
+		elem = in.Elem()
+	}
+	return func(item map[string]types.AttributeValue) error {
+		v := reflect.New(elem)
+		if err := dec.UnmarshalItem(item, v.Interface()); err != nil {
+			return err
+		}
+		if !ptr {
+			v = v.Elem()
+		}
+		err, _ := fv.Call([]reflect.Value{v})[0].Interface().(error)
+		return err
+	}, nil
+}
@@ -0,0 +1,201 @@
+package fuel
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/google/go-cmp/cmp"
+)
+
+// scanIDs collects the ids of scanned items
+type scanIDs struct {
+	mu  sync.Mutex
+	ids []string
+}
+
+func (s *scanIDs) add(item *dbItem) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ids = append(s.ids, item.ID)
+	return nil
+}
+
+func (s *scanIDs) sorted() []string {
+	ids := append([]string(nil), s.ids...)
+	sort.Strings(ids)
+	return ids
+}
+
+func scanTestIDs(n int) []string {
+	var ids []string
+	for i := 0; i < n; i++ {
+		ids = append(ids, strconv.Itoa(i))
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func TestScanEach(t *testing.T) {
+	ctx := context.Background()
+	table, client := newQueryTable(t, 10, 2)
+
+	for _, segments := range []int{0, 1, 3} {
+		var got scanIDs
+		var last Checkpoint
+		checkpoints := 0
+		err := table.Scan().Segments(segments).Workers(2).OnCheckpoint(func(cp Checkpoint) {
+			checkpoints++
+			last = cp
+		}).Each(ctx, got.add)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(scanTestIDs(10), got.sorted()); diff != "" {
+			t.Errorf("%d segments: missmatch (-want, +got):\n%s", segments, diff)
+		}
+		if !last.Done() || checkpoints != client.requests {
+			t.Errorf("%d segments: bad checkpoints: %d for %d requests, last %+v", segments, checkpoints, client.requests, last)
+		}
+		client.requests = 0
+	}
+
+	// values work too
+	count := 0
+	if err := table.Scan().Each(ctx, func(item dbItem) error { count++; return nil }); err != nil {
+		t.Fatal(err)
+	}
+	if count != 10 {
+		t.Errorf("missmatch: want 10 items, got %d", count)
+	}
+
+	for _, fn := range []interface{}{nil, func(dbItem) {}, func(a, b dbItem) error { return nil }, 1} {
+		if err := table.Scan().Each(ctx, fn); err == nil {
+			t.Errorf("callback %T: expected error, got nil", fn)
+		}
+	}
+}
+
+func TestScanInput(t *testing.T) {
+	table, _ := newQueryTable(t, 0, 0)
+	in, err := table.Scan().
+		Filter(Equal(Name("Status"), "open")).
+		Index("GSI1").
+		Consistent().
+		Project(ProjectPaths("id", "Status")).
+		input(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"filter":     "#n0 = :v0",
+		"projection": "id, #n0",
+		"index":      "GSI1",
+		"consistent": "true",
+		"segments":   "4",
+	}
+	got := map[string]string{
+		"filter":     aws.ToString(in.FilterExpression),
+		"projection": aws.ToString(in.ProjectionExpression),
+		"index":      aws.ToString(in.IndexName),
+		"consistent": strconv.FormatBool(aws.ToBool(in.ConsistentRead)),
+		"segments":   strconv.Itoa(int(aws.ToInt32(in.TotalSegments))),
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("missmatch (-want, +got):\n%s", diff)
+	}
+}
+
+func TestScanErrors(t *testing.T) {
+	ctx := context.Background()
+	errScan := errors.New("scan failed")
+
+	t.Run("cancel all and resume", func(t *testing.T) {
+		table, client := newQueryTable(t, 12, 2)
+		// segment 1 fails on its second page
+		client.scanErr = func(segment int, start string) error {
+			if segment == 1 && start != "" {
+				return errScan
+			}
+			return nil
+		}
+
+		var got scanIDs
+		var last Checkpoint
+		err := table.Scan().Segments(3).OnCheckpoint(func(cp Checkpoint) { last = cp }).Each(ctx, got.add)
+		var segErr *SegmentError
+		if !errors.As(err, &segErr) || segErr.Segment != 1 || !errors.Is(err, errScan) {
+			t.Fatalf("expected a segment 1 error, got %v", err)
+		}
+		if seg := last.Segments[1]; seg.Done || seg.LastKey == nil {
+			t.Errorf("bad checkpoint of the failed segment: %+v", seg)
+		}
+
+		client.scanErr = nil
+		err = table.Scan().Resume(last).OnCheckpoint(func(cp Checkpoint) { last = cp }).Each(ctx, got.add)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !last.Done() {
+			t.Errorf("expected the resumed scan to be done, got %+v", last)
+		}
+		// pages that weren't finished are processed again
+		seen := make(map[string]bool)
+		for _, id := range got.ids {
+			seen[id] = true
+		}
+		if len(seen) != 12 {
+			t.Errorf("missmatch: want 12 distinct items, got %d", len(seen))
+		}
+
+		if err := table.Scan().Segments(2).Resume(last).Each(ctx, got.add); err == nil {
+			t.Error("segment count mismatch: expected error, got nil")
+		}
+	})
+
+	t.Run("continue on error", func(t *testing.T) {
+		table, client := newQueryTable(t, 12, 2)
+		client.scanErr = func(segment int, start string) error {
+			if segment == 0 {
+				return errScan
+			}
+			return nil
+		}
+		errItem := errors.New("bad item")
+
+		var got scanIDs
+		err := table.Scan().Segments(3).Workers(1).ContinueOnError().Each(ctx, func(item *dbItem) error {
+			if item.ID == "5" {
+				return errItem
+			}
+			return got.add(item)
+		})
+		var errs SegmentErrors
+		if !errors.As(err, &errs) || len(errs) != 2 {
+			t.Fatalf("expected two segment errors, got %v", err)
+		}
+		sort.Slice(errs, func(i, j int) bool { return errs[i].Segment < errs[j].Segment })
+		if !errors.Is(errs[0], errScan) || !errors.Is(errs[1], errItem) {
+			t.Errorf("bad errors: %v", errs)
+		}
+		// segment 0 has 0, 11, 4 and 7, segment 1 stops at 5 after its first page,
+		// and segment 2 is complete
+		want := []string{"1", "10", "2", "3", "6", "9"}
+		if diff := cmp.Diff(want, got.sorted()); diff != "" {
+			t.Errorf("missmatch (-want, +got):\n%s", diff)
+		}
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		table, _ := newQueryTable(t, 4, 1)
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+		if err := table.Scan().Segments(2).Each(ctx, func(*dbItem) error { return nil }); !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	})
+}
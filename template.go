@@ -0,0 +1,142 @@
+package fuel
+
+import (
+	"fmt"
+	"strings"
+)
+
+// condition operator of templates
+const condTemplate = "template"
+
+// words of the expression syntax, which aren't attribute names when unquoted
+var expressionKeywords = map[string]bool{
+	"AND": true, "OR": true, "NOT": true, "BETWEEN": true, "IN": true,
+	"SET": true, "REMOVE": true, "ADD": true, "DELETE": true,
+}
+
+// Expr is an expression written as a template, where:
+//
+//	'name'  is a top-level attribute name, used as it is like AttributeName, such as 'Status' or 'a.b'
+//	$       is an attribute name or document path taken from the arguments (a string, Name or AttributeName)
+//	?       is a value taken from the arguments, encoded like Value (or any Operand, such as Size)
+//
+// Unquoted names, other than keywords such as AND and functions such as size(x), are document
+// paths, such as orders[0].sku. Names are escaped as needed (see Placeholders) and every value
+// becomes a : placeholder, so reserved words need no care:
+//
+//	fuel.Expr("'Status' = ? AND $ > ?", "active", "Created", t)
+//
+// A quote inside a quoted name is written twice. # and : placeholders can't be used directly.
+//
+// The result is a Condition, which can be combined with other conditions. Expressions rendered
+// with the same Placeholders share them, so several templates can be merged into one request;
+// Render also works for update and projection expressions written as templates.
+func Expr(template string, args ...interface{}) Condition {
+	var parts []string
+	var operands []Operand
+	var text strings.Builder
+
+	used := 0
+	nextArg := func() (interface{}, error) {
+		if used >= len(args) {
+			return nil, fmt.Errorf("dynamodb: template %q: not enough arguments", template)
+		}
+		used++
+		return args[used-1], nil
+	}
+	addOperand := func(op Operand) {
+		parts = append(parts, text.String())
+		text.Reset()
+		operands = append(operands, op)
+	}
+	fail := func(err error) Condition {
+		return Condition{err: err}
+	}
+
+	for i := 0; i < len(template); {
+		c := template[i]
+		switch {
+		case c == '?':
+			arg, err := nextArg()
+			if err != nil {
+				return fail(err)
+			}
+			addOperand(toOperand(arg))
+			i++
+		case c == '$':
+			arg, err := nextArg()
+			if err != nil {
+				return fail(err)
+			}
+			switch x := arg.(type) {
+			case string:
+				addOperand(Name(x))
+			case nameOperand, pathOperand:
+				addOperand(x.(Operand))
+			default:
+				return fail(fmt.Errorf("dynamodb: template %q: $ needs a string, Name or AttributeName, got %T", template, arg))
+			}
+			i++
+		case c == '\'':
+			name, n, err := quotedName(template[i:])
+			if err != nil {
+				return fail(fmt.Errorf("dynamodb: template %q: %w", template, err))
+			}
+			addOperand(AttributeName(name))
+			i += n
+		case c == '#' || c == ':':
+			return fail(fmt.Errorf("dynamodb: template %q: use quotes or $ for names and ? for values instead of %c", template, c))
+		case isIdentStart(c):
+			word, n := bareName(template[i:])
+			rest := strings.TrimLeft(template[i+n:], " \t\n")
+			if expressionKeywords[strings.ToUpper(word)] || strings.HasPrefix(rest, "(") {
+				text.WriteString(word)
+			} else {
+				addOperand(Name(word))
+			}
+			i += n
+		default:
+			text.WriteByte(c)
+			i++
+		}
+	}
+	if used != len(args) {
+		return fail(fmt.Errorf("dynamodb: template %q: too many arguments", template))
+	}
+	parts = append(parts, text.String())
+	return Condition{op: condTemplate, operands: operands, template: parts}
+}
+
+// quotedName returns the name quoted at the start of s and the length of the quoted text
+func quotedName(s string) (string, int, error) {
+	var b strings.Builder
+	for i := 1; i < len(s); i++ {
+		if s[i] != '\'' {
+			b.WriteByte(s[i])
+			continue
+		}
+		if i+1 < len(s) && s[i+1] == '\'' {
+			b.WriteByte('\'')
+			i++
+			continue
+		}
+		if b.Len() == 0 {
+			return "", 0, fmt.Errorf("empty quoted name")
+		}
+		return b.String(), i + 1, nil
+	}
+	return "", 0, fmt.Errorf("unterminated quoted name")
+}
+
+// bareName returns the unquoted name or document path at the start of s and its length
+func bareName(s string) (string, int) {
+	i := 0
+	for i < len(s) && (isIdentStart(s[i]) || isDigit(s[i]) || s[i] == '.' || s[i] == '[' || s[i] == ']') {
+		i++
+	}
+	return s[:i], i
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
@@ -0,0 +1,160 @@
+package fuel
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestExpr(t *testing.T) {
+	created := time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		cond       Condition
+		expr       string
+		names      map[string]string
+		values     map[string]types.AttributeValue
+		shouldFail bool
+	}{
+		{
+			name:  "quoted and $ names",
+			cond:  Expr("'Status' = ? AND $ > ?", "active", "Created", created),
+			expr:  "#n0 = :v0 AND Created > :v1",
+			names: map[string]string{"#n0": "Status"},
+			values: map[string]types.AttributeValue{
+				":v0": &types.AttributeValueMemberS{Value: "active"},
+				":v1": &types.AttributeValueMemberS{Value: "2021-04-01T00:00:00Z"},
+			},
+		},
+		{
+			name:  "bare names, keywords and functions",
+			cond:  Expr("begins_with(sku, ?) AND NOT contains(tags, ?) and size(orders[0].lines) BETWEEN ? AND ?", "A-", "old", 1, 9),
+			expr:  "begins_with(sku, :v0) AND NOT contains(tags, :v1) and size(orders[0].#n0) BETWEEN :v2 AND :v3",
+			names: map[string]string{"#n0": "lines"},
+			values: map[string]types.AttributeValue{
+				":v0": &types.AttributeValueMemberS{Value: "A-"},
+				":v1": &types.AttributeValueMemberS{Value: "old"},
+				":v2": &types.AttributeValueMemberN{Value: "1"},
+				":v3": &types.AttributeValueMemberN{Value: "9"},
+			},
+		},
+		{
+			name:  "names with dashes and quotes",
+			cond:  Expr("'first-name' = ? OR 'it''s' IN (?, ?)", "a", "b", Name("c")),
+			expr:  "#n0 = :v0 OR #n1 IN (:v1, c)",
+			names: map[string]string{"#n0": "first-name", "#n1": "it's"},
+			values: map[string]types.AttributeValue{
+				":v0": &types.AttributeValueMemberS{Value: "a"},
+				":v1": &types.AttributeValueMemberS{Value: "b"},
+			},
+		},
+		{
+			name:  "quoted names are literal",
+			cond:  Expr("'a.b' = ? AND 'x[1]' = ? AND a.b = ?", 1, 2, 3),
+			expr:  "#n0 = :v0 AND #n1 = :v1 AND a.b = :v2",
+			names: map[string]string{"#n0": "a.b", "#n1": "x[1]"},
+			values: map[string]types.AttributeValue{
+				":v0": &types.AttributeValueMemberN{Value: "1"},
+				":v1": &types.AttributeValueMemberN{Value: "2"},
+				":v2": &types.AttributeValueMemberN{Value: "3"},
+			},
+		},
+		{
+			name:  "update expression",
+			cond:  Expr("SET $ = $ + ? REMOVE 'Lock'", "Count", Name("Count"), 1),
+			expr:  "SET #n0 = #n0 + :v0 REMOVE #n1",
+			names: map[string]string{"#n0": "Count", "#n1": "Lock"},
+			values: map[string]types.AttributeValue{
+				":v0": &types.AttributeValueMemberN{Value: "1"},
+			},
+		},
+		{
+			name:  "combined",
+			cond:  And(Expr("name = ? OR b = ?", 1, 2), Equal(Name("name"), 3)),
+			expr:  "(#n0 = :v0 OR b = :v1) AND #n0 = :v2",
+			names: map[string]string{"#n0": "name"},
+			values: map[string]types.AttributeValue{
+				":v0": &types.AttributeValueMemberN{Value: "1"},
+				":v1": &types.AttributeValueMemberN{Value: "2"},
+				":v2": &types.AttributeValueMemberN{Value: "3"},
+			},
+		},
+		{
+			name:       "not enough arguments",
+			cond:       Expr("a = ? AND b = ?", 1),
+			shouldFail: true,
+		},
+		{
+			name:       "too many arguments",
+			cond:       Expr("a = ?", 1, 2),
+			shouldFail: true,
+		},
+		{
+			name:       "$ needs a name",
+			cond:       Expr("$ = ?", 1, 2),
+			shouldFail: true,
+		},
+		{
+			name:       "unterminated quote",
+			cond:       Expr("'a = ?", 1),
+			shouldFail: true,
+		},
+		{
+			name:       "raw placeholders",
+			cond:       Expr("#a = :a"),
+			shouldFail: true,
+		},
+		{
+			name:       "invalid path",
+			cond:       Expr("a..b = ?", 1),
+			shouldFail: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p Placeholders
+			expr, err := tt.cond.Render(&p)
+			if tt.shouldFail {
+				if err == nil {
+					t.Fatalf("expected error, got %q", expr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if expr != tt.expr {
+				t.Errorf("expression missmatch: want %q, got %q", tt.expr, expr)
+			}
+			if diff := cmp.Diff(tt.names, p.Names); diff != "" {
+				t.Errorf("names missmatch (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.values, p.Values); diff != "" {
+				t.Errorf("values missmatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestExprMerged(t *testing.T) {
+	var p Placeholders
+	update, err := Expr("SET 'Status' = ?", "done").Render(&p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cond, err := Expr("'Status' = ?", "pending").Render(&p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "SET #n0 = :v0"; update != want {
+		t.Errorf("update missmatch: want %q, got %q", want, update)
+	}
+	if want := "#n0 = :v1"; cond != want {
+		t.Errorf("condition missmatch: want %q, got %q", want, cond)
+	}
+	if diff := cmp.Diff(map[string]string{"#n0": "Status"}, p.Names); diff != "" {
+		t.Errorf("names missmatch (-want, +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,198 @@
+package fuel
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// Update is an update expression for UpdateItem. Build one with NewUpdate and its chainable methods,
+// then render it with the same Placeholders as the request's condition expression.
+type Update struct {
+	set    []updateAction
+	remove []Operand
+	add    []updateAction
+	delete []updateAction
+}
+
+type updateAction struct {
+	// a Name, or a pathOperand
+	path  Operand
+	value Operand
+}
+
+// NewUpdate returns an empty update expression.
+func NewUpdate() *Update {
+	return &Update{}
+}
+
+// Set adds the action SET path = v. v can be a value, a Name to copy another attribute,
+// or a value computed with IfNotExists, ListAppend, Plus or Minus.
+func (u *Update) Set(path string, v interface{}) *Update {
+	return u.setPath(Name(path), toOperand(v))
+}
+
+func (u *Update) setPath(path, value Operand) *Update {
+	u.set = append(u.set, updateAction{path: path, value: value})
+	return u
+}
+
+// SetIfNotExists sets path to v unless it already has a value.
+func (u *Update) SetIfNotExists(path string, v interface{}) *Update {
+	return u.Set(path, IfNotExists(path, v))
+}
+
+// Increment adds n to the number at path, which must exist. Use Add to treat a missing number as 0.
+func (u *Update) Increment(path string, n interface{}) *Update {
+	return u.Set(path, Plus(Name(path), n))
+}
+
+// Append appends the elements of the slice v to the list at path.
+func (u *Update) Append(path string, v interface{}) *Update {
+	return u.Set(path, ListAppend(Name(path), v))
+}
+
+// Remove adds the action REMOVE path for each of paths.
+func (u *Update) Remove(paths ...string) *Update {
+	for _, path := range paths {
+		u.remove = append(u.remove, Name(path))
+	}
+	return u
+}
+
+// Add adds the action ADD path v: v is added to the number at path,
+// or the members of v (a slice or map, encoded as a set) are added to the set at path.
+// A missing attribute is treated as 0 or the empty set.
+func (u *Update) Add(path string, v interface{}) *Update {
+	u.add = append(u.add, updateAction{path: Name(path), value: AsSet(v)})
+	return u
+}
+
+// Delete adds the action DELETE path v, removing the members of v
+// (a slice or map, encoded as a set) from the set at path.
+func (u *Update) Delete(path string, v interface{}) *Update {
+	u.delete = append(u.delete, updateAction{path: Name(path), value: AsSet(v)})
+	return u
+}
+
+// IsZero reports whether the update has no actions.
+func (u *Update) IsZero() bool {
+	return len(u.set) == 0 && len(u.remove) == 0 && len(u.add) == 0 && len(u.delete) == 0
+}
+
+// Render returns the update expression, adding the names and values it uses to p.
+func (u *Update) Render(p *Placeholders) (string, error) {
+	seen := make(map[string]bool)
+	// render renders path, checking that it isn't updated twice
+	render := func(path Operand) (string, error) {
+		key := fmt.Sprint(path)
+		if seen[key] {
+			return "", fmt.Errorf("dynamodb: update: %s is updated more than once", key)
+		}
+		seen[key] = true
+		return path.render(p)
+	}
+
+	var clauses []string
+	if len(u.set) > 0 {
+		actions := make([]string, 0, len(u.set))
+		for _, a := range u.set {
+			path, err := render(a.path)
+			if err != nil {
+				return "", err
+			}
+			value, err := a.value.render(p)
+			if err != nil {
+				return "", err
+			}
+			actions = append(actions, path+" = "+value)
+		}
+		clauses = append(clauses, "SET "+strings.Join(actions, ", "))
+	}
+	if len(u.remove) > 0 {
+		actions := make([]string, 0, len(u.remove))
+		for _, rm := range u.remove {
+			path, err := render(rm)
+			if err != nil {
+				return "", err
+			}
+			actions = append(actions, path)
+		}
+		clauses = append(clauses, "REMOVE "+strings.Join(actions, ", "))
+	}
+	for _, clause := range []struct {
+		keyword string
+		actions []updateAction
+	}{
+		{keyword: "ADD", actions: u.add},
+		{keyword: "DELETE", actions: u.delete},
+	} {
+		if len(clause.actions) == 0 {
+			continue
+		}
+		actions := make([]string, 0, len(clause.actions))
+		for _, a := range clause.actions {
+			path, err := render(a.path)
+			if err != nil {
+				return "", err
+			}
+			value, err := a.value.render(p)
+			if err != nil {
+				return "", err
+			}
+			if clause.keyword == "DELETE" && !isSetValue(p.Values[value]) {
+				return "", fmt.Errorf("dynamodb: update: DELETE %v needs a set, got %T", a.path, a.value.(valueOperand).v)
+			}
+			actions = append(actions, path+" "+value)
+		}
+		clauses = append(clauses, clause.keyword+" "+strings.Join(actions, ", "))
+	}
+	return strings.Join(clauses, " "), nil
+}
+
+func isSetValue(av types.AttributeValue) bool {
+	switch av.(type) {
+	case *types.AttributeValueMemberSS, *types.AttributeValueMemberNS, *types.AttributeValueMemberBS:
+		return true
+	}
+	return false
+}
+
+type funcOperand struct {
+	format   string
+	operands []Operand
+}
+
+func (f funcOperand) render(p *Placeholders) (string, error) {
+	args := make([]interface{}, 0, len(f.operands))
+	for _, op := range f.operands {
+		expr, err := op.render(p)
+		if err != nil {
+			return "", err
+		}
+		args = append(args, expr)
+	}
+	return fmt.Sprintf(f.format, args...), nil
+}
+
+// IfNotExists is the value of path if it exists, or v otherwise, for use with Update.Set.
+func IfNotExists(path string, v interface{}) Operand {
+	return funcOperand{format: "if_not_exists(%s, %s)", operands: []Operand{Name(path), toOperand(v)}}
+}
+
+// ListAppend is the concatenation of the lists a and b, for use with Update.Set.
+// Either can be a Name or a slice.
+func ListAppend(a, b interface{}) Operand {
+	return funcOperand{format: "list_append(%s, %s)", operands: []Operand{toOperand(a), toOperand(b)}}
+}
+
+// Plus is the number a + b, for use with Update.Set.
+func Plus(a, b interface{}) Operand {
+	return funcOperand{format: "%s + %s", operands: []Operand{toOperand(a), toOperand(b)}}
+}
+
+// Minus is the number a - b, for use with Update.Set.
+func Minus(a, b interface{}) Operand {
+	return funcOperand{format: "%s - %s", operands: []Operand{toOperand(a), toOperand(b)}}
+}
@@ -0,0 +1,137 @@
+package fuel
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestUpdateRender(t *testing.T) {
+	tests := []struct {
+		name       string
+		update     *Update
+		expr       string
+		names      map[string]string
+		values     map[string]types.AttributeValue
+		shouldFail bool
+	}{
+		{
+			name:   "empty",
+			update: NewUpdate(),
+			expr:   "",
+		},
+		{
+			name:   "set",
+			update: NewUpdate().Set("Name", "fuel").Set("Copy", Name("Name")).Set("Note", ""),
+			expr:   "SET #n0 = :v0, #n1 = #n0, Note = :v1",
+			names:  map[string]string{"#n0": "Name", "#n1": "Copy"},
+			values: map[string]types.AttributeValue{
+				":v0": &types.AttributeValueMemberS{Value: "fuel"},
+				":v1": &types.AttributeValueMemberS{Value: ""},
+			},
+		},
+		{
+			name: "set functions",
+			update: NewUpdate().
+				SetIfNotExists("Created", 100).
+				Append("Log", []string{"a"}).
+				Set("Stack", ListAppend([]int{1}, Name("Stack"))).
+				Increment("Count", 1).
+				Set("Left", Minus(IfNotExists("Left", 10), 1)),
+			expr: "SET Created = if_not_exists(Created, :v0), #n0 = list_append(#n0, :v1), Stack = list_append(:v2, Stack), " +
+				"#n1 = #n1 + :v3, #n2 = if_not_exists(#n2, :v4) - :v5",
+			names: map[string]string{"#n0": "Log", "#n1": "Count", "#n2": "Left"},
+			values: map[string]types.AttributeValue{
+				":v0": &types.AttributeValueMemberN{Value: "100"},
+				":v1": &types.AttributeValueMemberL{Value: []types.AttributeValue{&types.AttributeValueMemberS{Value: "a"}}},
+				":v2": &types.AttributeValueMemberL{Value: []types.AttributeValue{&types.AttributeValueMemberN{Value: "1"}}},
+				":v3": &types.AttributeValueMemberN{Value: "1"},
+				":v4": &types.AttributeValueMemberN{Value: "10"},
+				":v5": &types.AttributeValueMemberN{Value: "1"},
+			},
+		},
+		{
+			name:   "set a set",
+			update: NewUpdate().Set("Tags", AsSet([]string{"a", "b"})),
+			expr:   "SET Tags = :v0",
+			values: map[string]types.AttributeValue{
+				":v0": &types.AttributeValueMemberSS{Value: []string{"a", "b"}},
+			},
+		},
+		{
+			name:   "all clauses",
+			update: NewUpdate().Delete("Tags", map[string]bool{"old": true}).Add("Count", 2).Add("Scores", []int{7}).Remove("Temp", "List[1]").Set("A", 1),
+			expr:   "SET A = :v0 REMOVE #n0, #n1[1] ADD #n2 :v1, Scores :v2 DELETE Tags :v3",
+			names:  map[string]string{"#n0": "Temp", "#n1": "List", "#n2": "Count"},
+			values: map[string]types.AttributeValue{
+				":v0": &types.AttributeValueMemberN{Value: "1"},
+				":v1": &types.AttributeValueMemberN{Value: "2"},
+				":v2": &types.AttributeValueMemberNS{Value: []string{"7"}},
+				":v3": &types.AttributeValueMemberSS{Value: []string{"old"}},
+			},
+		},
+		{
+			name:       "empty set",
+			update:     NewUpdate().Add("Tags", []string{}),
+			shouldFail: true,
+		},
+		{
+			name:       "delete needs a set",
+			update:     NewUpdate().Delete("Count", 1),
+			shouldFail: true,
+		},
+		{
+			name:       "same path twice",
+			update:     NewUpdate().Set("A", 1).Remove("A"),
+			shouldFail: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p Placeholders
+			expr, err := tt.update.Render(&p)
+			if tt.shouldFail {
+				if err == nil {
+					t.Fatalf("expected error, got %q", expr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if expr != tt.expr {
+				t.Errorf("expression missmatch: want %q, got %q", tt.expr, expr)
+			}
+			if diff := cmp.Diff(tt.names, p.Names); diff != "" {
+				t.Errorf("names missmatch (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.values, p.Values, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+				t.Errorf("values missmatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdateWithCondition(t *testing.T) {
+	var p Placeholders
+	update, err := NewUpdate().Set("Status", "done").Add("Size", 1).Render(&p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cond, err := And(Equal(Name("Status"), "pending"), Equal(Name("Size"), 3)).Render(&p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "SET #n0 = :v0 ADD #n1 :v1"; update != want {
+		t.Errorf("update missmatch: want %q, got %q", want, update)
+	}
+	if want := "#n0 = :v2 AND #n1 = :v3"; cond != want {
+		t.Errorf("condition missmatch: want %q, got %q", want, cond)
+	}
+	if len(p.Names) != 2 || len(p.Values) != 4 {
+		t.Errorf("placeholder count missmatch: %v %v", p.Names, p.Values)
+	}
+}